@@ -1,62 +1,806 @@
 package equinix
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/equinix/ecx-go/v2"
 	"github.com/equinix/ne-go"
 	"github.com/equinix/oauth2-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	xoauth2 "golang.org/x/oauth2"
 )
 
 //Config is the configuration structure used to instantiate the Equinix
 //provider.
 type Config struct {
-	BaseURL        string
-	ClientID       string
-	ClientSecret   string
+	BaseURL      string
+	ClientID     string
+	ClientSecret string
+	//Token is an API access token used instead of acquiring one
+	//with client credentials
+	Token string
+	//RequestTimeout bounds a single API request, excluding acquisition
+	//of access token. Zero means default timeout
 	RequestTimeout time.Duration
-	PageSize       int
+	//AuthRequestTimeout bounds a single OAuth token request.
+	//Zero means default timeout
+	AuthRequestTimeout time.Duration
+	//ProxyURL is an URL of proxy that all requests are sent through,
+	//optionally with proxy credentials. When empty, proxy is determined
+	//by HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+	ProxyURL string
+	PageSize int
+	//AllowedNotificationDomains restricts email domains that can be
+	//used in Fabric connection notifications. Empty list means no restriction
+	AllowedNotificationDomains []string
+	//MaxConcurrentECX limits number of concurrent mutating operations
+	//on Equinix Fabric resources. Zero means no limit
+	MaxConcurrentECX int
+	//MaxConcurrentNE limits number of concurrent mutating operations
+	//on Network Edge resources. Zero means no limit
+	MaxConcurrentNE int
+	//LookupCacheTTL defines how long results of read-only lookups,
+	//like ports or service profiles, are reused. Zero disables caching
+	LookupCacheTTL time.Duration
+	//RequestHeaders are additional HTTP headers set on every API request
+	RequestHeaders map[string]string
+	//AuthScopes are OAuth scopes requested when acquiring access token.
+	//Empty list means scopes granted by default
+	AuthScopes []string
+	//LifecycleWebhookURL is an URL that is notified about created
+	//and removed Fabric connections. Empty URL disables notifications
+	LifecycleWebhookURL string
+	//FabricAPIVersion selects Equinix Fabric client implementation.
+	//Empty value means default version
+	FabricAPIVersion string
+	//PollInterval is a minimal interval between status checks made while
+	//waiting for Fabric connections to be provisioned, updated or removed.
+	//Zero means default interval
+	PollInterval time.Duration
+	//MaxRetries limits number of times API request is retried after
+	//transient failure. Zero disables retries
+	MaxRetries int
+	//RetryWaitMin and RetryWaitMax bound exponential backoff between
+	//retries of API requests. Zero means default wait
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	//AuthMaxRetries limits number of times OAuth token request is retried
+	//after transient failure. Zero disables retries
+	AuthMaxRetries int
+	//VerboseErrors enables including full API error details
+	//in diagnostics of failed operations
+	VerboseErrors bool
 
-	ecx ecx.Client
-	ne  ne.Client
+	ecx           ecx.Client
+	ne            ne.Client
+	ecxSemaphore  semaphore
+	portLocks     *keyedMutex
+	neSemaphore   semaphore
+	rateLimit     *rateLimitTransport
+	lookupCache   *lookupCache
+	webhookClient *http.Client
+	httpClient    *http.Client
+	clientCtx     context.Context
+	ecxClientsMu  sync.Mutex
+	ecxClients    map[time.Duration]ecx.Client
 }
 
 //Load function validates configuration structure fields and configures
 //all required API clients.
 func (c *Config) Load(ctx context.Context) error {
 	if c.BaseURL == "" {
-		return fmt.Errorf("baseURL cannot be empty")
+		c.BaseURL = defaultBaseURL
 	}
-	if c.ClientID == "" {
+	baseURL, err := normalizeBaseURL(c.BaseURL)
+	if err != nil {
+		return err
+	}
+	c.BaseURL = baseURL
+	if c.Token == "" && c.ClientID == "" {
 		return fmt.Errorf("clientId cannot be empty")
 	}
-	if c.ClientSecret == "" {
+	if c.Token == "" && c.ClientSecret == "" {
 		return fmt.Errorf("clientSecret cannot be empty")
 	}
-	authConfig := oauth2.Config{
-		ClientID:     c.ClientID,
-		ClientSecret: c.ClientSecret,
-		BaseURL:      c.BaseURL}
-	authClient := authConfig.New(ctx)
-	authClient.Timeout = c.requestTimeout()
-	authClient.Transport = logging.NewTransport("Equinix", authClient.Transport)
-	ecxClient := ecx.NewClient(ctx, c.BaseURL, authClient)
+	if err := validatePageSize(c.PageSize); err != nil {
+		return err
+	}
+	if err := validateRequestHeaders(c.RequestHeaders); err != nil {
+		return err
+	}
+	if c.RetryWaitMin > 0 && c.RetryWaitMax > 0 && c.RetryWaitMin > c.RetryWaitMax {
+		return fmt.Errorf("retryWaitMin cannot be greater than retryWaitMax")
+	}
+	baseTransport, err := newBaseTransport(c.ProxyURL)
+	if err != nil {
+		return err
+	}
+	authClient := c.newAuthClient(ctx, baseTransport)
+	c.rateLimit = &rateLimitTransport{RoundTripper: authClient.Transport}
+	authClient.Transport = logging.NewTransport("Equinix", c.rateLimit)
+	if c.MaxRetries > 0 {
+		authClient.Transport = &retryTransport{RoundTripper: authClient.Transport, maxRetries: c.MaxRetries,
+			waitMin: c.RetryWaitMin, waitMax: c.RetryWaitMax}
+	}
+	if len(c.RequestHeaders) > 0 {
+		authClient.Transport = &headersTransport{RoundTripper: authClient.Transport, headers: c.RequestHeaders}
+	}
+	ecxClient, err := newECXClient(ctx, c.FabricAPIVersion, c.BaseURL, authClient, c.PageSize)
+	if err != nil {
+		return err
+	}
 	neClient := ne.NewClient(ctx, c.BaseURL, authClient)
 	if c.PageSize > 0 {
-		ecxClient.SetPageSize(c.PageSize)
 		neClient.SetPageSize(c.PageSize)
 	}
 	c.ecx = ecxClient
 	c.ne = neClient
+	c.httpClient = authClient
+	c.clientCtx = ctx
+	c.ecxSemaphore = newSemaphore(c.MaxConcurrentECX)
+	c.portLocks = newKeyedMutex()
+	c.neSemaphore = newSemaphore(c.MaxConcurrentNE)
+	c.lookupCache = newLookupCache(c.LookupCacheTTL)
+	c.webhookClient = &http.Client{Transport: baseTransport, Timeout: c.requestTimeout()}
+	return nil
+}
+
+//newAuthClient creates HTTP client that authorizes requests with given
+//access token or with token acquired using client credentials
+func (c *Config) newAuthClient(ctx context.Context, baseTransport http.RoundTripper) *http.Client {
+	//API requests are bounded by timeout transport, below OAuth transport,
+	//so acquisition of access token is bounded by its own timeout only
+	apiTransport := &timeoutTransport{RoundTripper: baseTransport, timeout: c.requestTimeout()}
+	if c.Token != "" {
+		return &http.Client{Transport: &xoauth2.Transport{
+			Source: xoauth2.StaticTokenSource(&xoauth2.Token{AccessToken: c.Token}),
+			Base:   apiTransport,
+		}}
+	}
+	authConfig := oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		BaseURL:      c.BaseURL}
+	tokenTransport := baseTransport
+	if len(c.AuthScopes) > 0 {
+		tokenTransport = &authScopesTransport{RoundTripper: tokenTransport, scopes: c.AuthScopes}
+	}
+	//token requests are safe to repeat, so they are retried on any
+	//transient failure; rejected credentials are not retried
+	if c.AuthMaxRetries > 0 {
+		tokenTransport = &retryTransport{RoundTripper: tokenTransport, maxRetries: c.AuthMaxRetries,
+			waitMin: c.RetryWaitMin, waitMax: c.RetryWaitMax, idempotent: true}
+	}
+	tokenClient := &http.Client{Transport: tokenTransport, Timeout: c.authRequestTimeout()}
+	return &http.Client{Transport: &xoauth2.Transport{
+		Source: authConfig.TokenSource(ctx, tokenClient),
+		Base:   apiTransport,
+	}}
+}
+
+//newBaseTransport creates transport that API, OAuth and webhook requests
+//are sent with. Given proxy URL overrides proxy settings from environment
+func newBaseTransport(proxyURL string) (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL == "" {
+		return transport, nil
+	}
+	//proxy URL may carry credentials, so it is not included in errors
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("proxyURL is not valid URL")
+	}
+	if !isStringInSlice(u.Scheme, []string{"http", "https", "socks5"}) || u.Host == "" {
+		return nil, fmt.Errorf("proxyURL has to be an absolute URL with http, https or socks5 scheme")
+	}
+	transport.Proxy = http.ProxyURL(u)
+	return transport, nil
+}
+
+//defaultBaseURL is a base URL of production Equinix API
+const defaultBaseURL = "https://api.equinix.com"
+
+//normalizeBaseURL verifies that given base URL is an absolute HTTPS URL
+//without path, query or fragment and returns it without trailing slash
+func normalizeBaseURL(baseURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return "", fmt.Errorf("baseURL %q is not valid: %s", baseURL, err)
+	}
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("baseURL %q has to use https scheme", baseURL)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("baseURL %q has no host", baseURL)
+	}
+	if strings.TrimRight(u.Path, "/") != "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("baseURL %q cannot contain path, query or fragment, i.e. use %q", baseURL, u.Scheme+"://"+u.Host)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
+const (
+	minPageSize = 100
+	maxPageSize = 1000
+)
+
+//validatePageSize checks if given page size is within supported bounds.
+//Zero page size means client specific default
+func validatePageSize(pageSize int) error {
+	if pageSize != 0 && (pageSize < minPageSize || pageSize > maxPageSize) {
+		return fmt.Errorf("pageSize has to be between %d and %d, got %d", minPageSize, maxPageSize, pageSize)
+	}
 	return nil
 }
 
+const fabricAPIVersionV2 = "v2"
+
+var supportedFabricAPIVersions = []string{fabricAPIVersionV2}
+
+//newECXClient creates Equinix Fabric client for a given API version.
+//Empty version means default, v2, version
+func newECXClient(ctx context.Context, version, baseURL string, httpClient *http.Client, pageSize int) (ecx.Client, error) {
+	switch version {
+	case "", fabricAPIVersionV2:
+		client := ecx.NewClient(ctx, baseURL, httpClient)
+		if pageSize > 0 {
+			client.SetPageSize(pageSize)
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("fabricAPIVersion %q is not supported, supported versions: %s", version, strings.Join(supportedFabricAPIVersions, ", "))
+	}
+}
+
+//ecxWithTimeout returns Equinix Fabric client which requests time out
+//after a given duration. Clients share authentication and transport with
+//the default client. Non positive duration means provider's request timeout
+func (c *Config) ecxWithTimeout(timeout time.Duration) ecx.Client {
+	if timeout <= 0 || timeout == c.requestTimeout() || c.httpClient == nil {
+		return c.ecx
+	}
+	c.ecxClientsMu.Lock()
+	defer c.ecxClientsMu.Unlock()
+	if client, ok := c.ecxClients[timeout]; ok {
+		return client
+	}
+	httpClient := *c.httpClient
+	httpClient.Transport = &requestTimeoutTransport{RoundTripper: c.httpClient.Transport, timeout: timeout}
+	client, err := newECXClient(c.clientCtx, c.FabricAPIVersion, c.BaseURL, &httpClient, c.PageSize)
+	if err != nil {
+		log.Printf("[WARN] using default Equinix Fabric client, error creating client with %s timeout: %s", timeout, err)
+		return c.ecx
+	}
+	if c.ecxClients == nil {
+		c.ecxClients = make(map[time.Duration]ecx.Client)
+	}
+	c.ecxClients[timeout] = client
+	return client
+}
+
+//ecxWithContext returns Equinix Fabric client which requests are cancelled
+//once a given context is done. Requests also time out after a given duration,
+//like ones of client returned by ecxWithTimeout
+func (c *Config) ecxWithContext(ctx context.Context, timeout time.Duration) ecx.Client {
+	if c.httpClient == nil {
+		return c.ecx
+	}
+	httpClient := *c.httpClient
+	if timeout > 0 && timeout != c.requestTimeout() {
+		httpClient.Transport = &requestTimeoutTransport{RoundTripper: c.httpClient.Transport, timeout: timeout}
+	}
+	client, err := newECXClient(ctx, c.FabricAPIVersion, c.BaseURL, &httpClient, c.PageSize)
+	if err != nil {
+		log.Printf("[WARN] using default Equinix Fabric client, error creating client with request context: %s", err)
+		return c.ecx
+	}
+	return client
+}
+
 func (c *Config) requestTimeout() time.Duration {
 	if c.RequestTimeout == 0 {
-		return 5 * time.Second
+		return defaultRequestTimeout
 	}
 	return c.RequestTimeout
 }
+
+func (c *Config) authRequestTimeout() time.Duration {
+	if c.AuthRequestTimeout == 0 {
+		return defaultAuthRequestTimeout
+	}
+	return c.AuthRequestTimeout
+}
+
+func (c *Config) pollInterval() time.Duration {
+	if c.PollInterval == 0 {
+		return defaultPollInterval
+	}
+	return c.PollInterval
+}
+
+//retryAfter returns duration that client should wait before sending
+//next request after API responded with rate limit error
+func (c *Config) retryAfter() time.Duration {
+	if c.rateLimit == nil {
+		return defaultRetryAfter
+	}
+	return c.rateLimit.lastRetryAfter()
+}
+
+const defaultRetryAfter = 5 * time.Second
+
+const defaultPollInterval = 2 * time.Second
+
+const (
+	defaultRequestTimeout     = 30 * time.Second
+	defaultAuthRequestTimeout = 30 * time.Second
+)
+
+const (
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+//rateLimitTransport records Retry-After header value
+//of rate limited (HTTP 429) responses
+type rateLimitTransport struct {
+	http.RoundTripper
+	mu         sync.Mutex
+	retryAfter time.Duration
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.mu.Lock()
+		t.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		t.mu.Unlock()
+	}
+	return resp, err
+}
+
+func (t *rateLimitTransport) lastRetryAfter() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.retryAfter <= 0 {
+		return defaultRetryAfter
+	}
+	return t.retryAfter
+}
+
+//parseRetryAfter parses Retry-After header value, given either
+//in seconds or as HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return defaultRetryAfter
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return defaultRetryAfter
+}
+
+type requestTimeoutKey struct{}
+
+//requestTimeoutTransport overrides timeout of requests made through it.
+//Timeout is passed in request context to underlying timeoutTransport
+type requestTimeoutTransport struct {
+	http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *requestTimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.RoundTripper.RoundTrip(req.WithContext(context.WithValue(req.Context(), requestTimeoutKey{}, t.timeout)))
+}
+
+//timeoutTransport cancels single request that does not complete,
+//including reading of response body, within a timeout
+type timeoutTransport struct {
+	http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.timeout
+	if v, ok := req.Context().Value(requestTimeoutKey{}).(time.Duration); ok && v > 0 {
+		timeout = v
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.RoundTripper.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+//cancelOnCloseBody releases request context once response body is closed
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+//headersTransport sets additional headers on every request
+type headersTransport struct {
+	http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
+//retryTransport retries idempotent requests that failed with transient errors,
+//like connection errors, rate limited (HTTP 429) or server error responses,
+//waiting with exponential backoff or as long as Retry-After header says,
+//up to maximum wait. Other requests, like connection creates, are not retried
+//as they could be processed more than once
+type retryTransport struct {
+	http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+	//idempotent treats all requests as idempotent ones
+	idempotent bool
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+		resp, err := t.RoundTripper.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !isRetryableRequest(req, resp, err, t.idempotent) {
+			return resp, err
+		}
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("[DEBUG] retrying %s %s in %s, attempt %d of %d", req.Method, req.URL.Path, wait, attempt+1, t.maxRetries)
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+//backoff returns duration to wait before given retry attempt
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	waitMin, waitMax := t.waitMin, t.waitMax
+	if waitMin <= 0 {
+		waitMin = defaultRetryWaitMin
+	}
+	if waitMax <= 0 {
+		waitMax = defaultRetryWaitMax
+	}
+	if resp != nil {
+		if v := resp.Header.Get("Retry-After"); v != "" {
+			if wait := parseRetryAfter(v, time.Now()); wait < waitMax {
+				return wait
+			}
+			return waitMax
+		}
+	}
+	wait := waitMin
+	for i := 0; i < attempt && wait < waitMax; i++ {
+		wait *= 2
+	}
+	if wait > waitMax {
+		return waitMax
+	}
+	return wait
+}
+
+func isRetryableRequest(req *http.Request, resp *http.Response, err error, idempotent bool) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if !idempotent && !isStringInSlice(req.Method, []string{http.MethodGet, http.MethodHead, http.MethodOptions}) {
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+//authScopesTransport adds scope parameter to the body
+//of OAuth token requests
+type authScopesTransport struct {
+	http.RoundTripper
+	scopes []string
+}
+
+func (t *authScopesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	tokenReq := make(map[string]interface{})
+	if err := json.Unmarshal(body, &tokenReq); err != nil {
+		return nil, fmt.Errorf("cannot add scopes to token request: %s", err)
+	}
+	tokenReq["scope"] = strings.Join(t.scopes, " ")
+	if body, err = json.Marshal(tokenReq); err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	return t.RoundTripper.RoundTrip(req)
+}
+
+const (
+	lifecycleActionCreate = "create"
+	lifecycleActionDelete = "delete"
+)
+
+//lifecycleEvent describes resource lifecycle change
+//posted to lifecycle webhook
+type lifecycleEvent struct {
+	Action string `json:"action"`
+	UUID   string `json:"uuid"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+//notifyLifecycleWebhook posts given event to configured lifecycle webhook.
+//Delivery failures are logged and do not fail the operation
+func (c *Config) notifyLifecycleWebhook(ctx context.Context, event lifecycleEvent) {
+	if c.LifecycleWebhookURL == "" {
+		return
+	}
+	if err := postLifecycleEvent(ctx, c.webhookClient, c.LifecycleWebhookURL, event); err != nil {
+		log.Printf("[WARN] failed to notify lifecycle webhook about %s of %q: %s", event.Action, event.UUID, err)
+	}
+}
+
+func postLifecycleEvent(ctx context.Context, client *http.Client, url string, event lifecycleEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+var headerNameRegexp = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+//validateRequestHeaders checks if given header names are valid HTTP tokens
+//and header values do not contain control characters
+func validateRequestHeaders(headers map[string]string) error {
+	for k, v := range headers {
+		if !headerNameRegexp.MatchString(k) {
+			return fmt.Errorf("request header name %q is not valid", k)
+		}
+		if strings.ContainsAny(v, "\r\n\x00") {
+			return fmt.Errorf("request header %q value contains invalid characters", k)
+		}
+	}
+	return nil
+}
+
+//semaphore limits number of concurrently executed operations.
+//Nil semaphore does not impose any limit
+type semaphore chan struct{}
+
+func newSemaphore(size int) semaphore {
+	if size < 1 {
+		return nil
+	}
+	return make(semaphore, size)
+}
+
+func (s semaphore) acquire() {
+	if s != nil {
+		s <- struct{}{}
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+//keyedMutex serializes operations that share a key, while operations
+//with different keys proceed concurrently. Nil mutex does not lock anything
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*sync.Mutex)}
+}
+
+//lock locks all given non empty keys and returns function that unlocks them.
+//Keys are locked in sorted order, so operations locking many keys
+//do not deadlock
+func (m *keyedMutex) lock(keys ...string) func() {
+	if m == nil {
+		return func() {}
+	}
+	unique := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != "" && !isStringInSlice(key, unique) {
+			unique = append(unique, key)
+		}
+	}
+	sort.Strings(unique)
+	locked := make([]*sync.Mutex, 0, len(unique))
+	for _, key := range unique {
+		m.mu.Lock()
+		l, ok := m.locks[key]
+		if !ok {
+			l = &sync.Mutex{}
+			m.locks[key] = l
+		}
+		m.mu.Unlock()
+		l.Lock()
+		locked = append(locked, l)
+	}
+	return func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			locked[i].Unlock()
+		}
+	}
+}
+
+//lookupCache keeps results of read-only API lookups for a given time.
+//Cache lives in provider's memory, so it is discarded once Terraform
+//operation completes. Nil cache does not cache anything
+type lookupCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]lookupCacheEntry
+}
+
+type lookupCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newLookupCache(ttl time.Duration) *lookupCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &lookupCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]lookupCacheEntry),
+	}
+}
+
+//get returns cached value for a given key or, when value is missing
+//or expired, calls fetch function and caches its successful result
+func (c *lookupCache) get(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fetch()
+	}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.value, nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = lookupCacheEntry{value: value, expires: c.now().Add(c.ttl)}
+	c.mu.Unlock()
+	return value, nil
+}
+
+//invalidate removes cached value for a given key
+func (c *lookupCache) invalidate(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+func (c *Config) getUserPorts() ([]ecx.Port, error) {
+	v, err := c.lookupCache.get("ecx/ports", func() (interface{}, error) {
+		return c.ecx.GetUserPorts()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]ecx.Port), nil
+}
+
+func (c *Config) getL2SellerProfiles() ([]ecx.L2ServiceProfile, error) {
+	v, err := c.lookupCache.get("ecx/sellerprofiles", func() (interface{}, error) {
+		return c.ecx.GetL2SellerProfiles()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]ecx.L2ServiceProfile), nil
+}
+
+func (c *Config) getL2ServiceProfile(uuid string) (*ecx.L2ServiceProfile, error) {
+	v, err := c.lookupCache.get("ecx/serviceprofile/"+uuid, func() (interface{}, error) {
+		return c.ecx.GetL2ServiceProfile(uuid)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*ecx.L2ServiceProfile), nil
+}
+
+func (c *Config) getNetworkAccounts(metroCode string) ([]ne.Account, error) {
+	v, err := c.lookupCache.get("ne/accounts/"+metroCode, func() (interface{}, error) {
+		return c.ne.GetAccounts(metroCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]ne.Account), nil
+}
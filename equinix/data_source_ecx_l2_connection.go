@@ -0,0 +1,69 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceECXL2Connection() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceECXL2ConnectionRead,
+		Description: "Use this data source to get details of Equinix Fabric layer 2 connection with a given UUID",
+		Schema:      createECXL2ConnectionDataSourceSchema(),
+	}
+}
+
+func createECXL2ConnectionDataSourceSchema() map[string]*schema.Schema {
+	sch := createECXL2ConnectionPairConnectionSchema()
+	sch[ecxL2ConnectionSchemaNames["UUID"]] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+		Description:  ecxL2ConnectionDescriptions["UUID"],
+	}
+	sch[ecxL2ConnectionSchemaNames["SecondaryConnection"]] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: createECXL2ConnectionPairConnectionSchema(),
+		},
+		Description: ecxL2ConnectionDescriptions["SecondaryConnection"],
+	}
+	return sch
+}
+
+func dataSourceECXL2ConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conf := m.(*Config)
+	var diags diag.Diagnostics
+	uuid := d.Get(ecxL2ConnectionSchemaNames["UUID"]).(string)
+	primary, secondary, err := getECXL2ConnectionWithRedundant(conf.ecx.GetL2Connection, uuid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(ecx.StringValue(primary.UUID))
+	if err := updateECXL2ConnectionDataSource(primary, secondary, d); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func updateECXL2ConnectionDataSource(primary *ecx.L2Connection, secondary *ecx.L2Connection, d *schema.ResourceData) error {
+	for key, value := range flattenECXL2ConnectionPairConnection(primary).([]interface{})[0].(map[string]interface{}) {
+		if err := d.Set(key, value); err != nil {
+			return fmt.Errorf("error reading %s: %s", key, err)
+		}
+	}
+	var secondaryConn interface{} = []interface{}{}
+	if secondary != nil {
+		secondaryConn = flattenECXL2ConnectionPairConnection(secondary)
+	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["SecondaryConnection"], secondaryConn); err != nil {
+		return fmt.Errorf("error reading SecondaryConnection: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccECXL2ConnectionDataSource(t *testing.T) {
+	t.Parallel()
+	connUUID, err := getFromEnv(tstL2ConnectionUUIDEnvVar)
+	if err != nil {
+		t.Skipf("skipping: %s", err)
+	}
+	context := map[string]interface{}{
+		"resourceName": "tf-conn",
+		"uuid":         connUUID,
+	}
+	resourceName := fmt.Sprintf("data.equinix_ecx_l2_connection.%s", context["resourceName"].(string))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccECXL2ConnectionDataSource(context),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "uuid", connUUID),
+					resource.TestCheckResourceAttrSet(resourceName, "name"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+					resource.TestCheckResourceAttrSet(resourceName, "speed"),
+				),
+			},
+		},
+	})
+}
+
+func testAccECXL2ConnectionDataSource(ctx map[string]interface{}) string {
+	return nprintf(`
+data "equinix_ecx_l2_connection" "%{resourceName}" {
+  uuid = "%{uuid}"
+}
+`, ctx)
+}
@@ -0,0 +1,215 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var ecxL2ConnectionPairSchemaNames = map[string]string{
+	"UUID":          "uuid",
+	"PrimaryUUID":   "primary_uuid",
+	"SecondaryUUID": "secondary_uuid",
+	"Primary":       "primary",
+	"Secondary":     "secondary",
+}
+
+var ecxL2ConnectionPairDescriptions = map[string]string{
+	"UUID":          "Unique identifier of either primary or secondary connection of a pair",
+	"PrimaryUUID":   "Unique identifier of the primary connection",
+	"SecondaryUUID": "Unique identifier of the secondary connection, empty for non redundant connections",
+	"Primary":       "Details of the primary connection",
+	"Secondary":     "Details of the secondary connection, empty for non redundant connections",
+}
+
+func dataSourceECXL2ConnectionPair() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceECXL2ConnectionPairRead,
+		Description: "Use this data source to get details of both primary and secondary Equinix Fabric layer 2 connection given UUID of any of them",
+		Schema: map[string]*schema.Schema{
+			ecxL2ConnectionPairSchemaNames["UUID"]: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  ecxL2ConnectionPairDescriptions["UUID"],
+			},
+			ecxL2ConnectionPairSchemaNames["PrimaryUUID"]: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: ecxL2ConnectionPairDescriptions["PrimaryUUID"],
+			},
+			ecxL2ConnectionPairSchemaNames["SecondaryUUID"]: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: ecxL2ConnectionPairDescriptions["SecondaryUUID"],
+			},
+			ecxL2ConnectionPairSchemaNames["Primary"]: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: createECXL2ConnectionPairConnectionSchema(),
+				},
+				Description: ecxL2ConnectionPairDescriptions["Primary"],
+			},
+			ecxL2ConnectionPairSchemaNames["Secondary"]: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: createECXL2ConnectionPairConnectionSchema(),
+				},
+				Description: ecxL2ConnectionPairDescriptions["Secondary"],
+			},
+		},
+	}
+}
+
+func createECXL2ConnectionPairConnectionSchema() map[string]*schema.Schema {
+	connSchema := make(map[string]*schema.Schema)
+	for _, name := range []string{"UUID", "Name", "ProfileUUID", "SpeedUnit", "SpeedHuman", "Status", "ProviderStatus",
+		"PurchaseOrderNumber", "PortUUID", "DeviceUUID", "NamedTag", "ZSidePortUUID", "ZSideVlan", "SellerRegion",
+		"SellerMetroCode", "AuthorizationKey", "RedundantUUID", "RedundancyType", "LifecycleStage", "BGPASN", "Fingerprint"} {
+		connSchema[ecxL2ConnectionSchemaNames[name]] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions[name],
+		}
+	}
+	for _, name := range []string{"Speed", "BandwidthInMbps", "DeviceInterfaceID", "VlanSTag", "VlanCTag", "ZSideVlanSTag", "ZSideVlanCTag"} {
+		connSchema[ecxL2ConnectionSchemaNames[name]] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions[name],
+		}
+	}
+	connSchema[ecxL2ConnectionSchemaNames["IsPrimary"]] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: ecxL2ConnectionDescriptions["IsPrimary"],
+	}
+	connSchema[ecxL2ConnectionSchemaNames["Notifications"]] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: ecxL2ConnectionDescriptions["Notifications"],
+	}
+	connSchema[ecxL2ConnectionSchemaNames["AdditionalInfo"]] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				ecxL2ConnectionAdditionalInfoSchemaNames["Name"]: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: ecxL2ConnectionAdditionalInfoDescriptions["Name"],
+				},
+				ecxL2ConnectionAdditionalInfoSchemaNames["Value"]: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: ecxL2ConnectionAdditionalInfoDescriptions["Value"],
+				},
+			},
+		},
+		Description: ecxL2ConnectionDescriptions["AdditionalInfo"],
+	}
+	//remaining attributes are computed in the resource as well
+	resourceSchema := createECXL2ConnectionResourceSchema()
+	for _, name := range []string{"RequiredConfirmationData", "Actions", "CloudDetails"} {
+		connSchema[ecxL2ConnectionSchemaNames[name]] = resourceSchema[ecxL2ConnectionSchemaNames[name]]
+	}
+	return connSchema
+}
+
+//flattenECXL2ConnectionPairConnection transforms given connection to a list
+//with a single element, as described by createECXL2ConnectionPairConnectionSchema
+func flattenECXL2ConnectionPairConnection(conn *ecx.L2Connection) interface{} {
+	transformed := flattenECXL2ConnectionSecondary(nil, conn).([]interface{})[0].(map[string]interface{})
+	transformed[ecxL2ConnectionSchemaNames["SpeedHuman"]] = formatECXL2ConnectionSpeed(conn.Speed, conn.SpeedUnit)
+	transformed[ecxL2ConnectionSchemaNames["BandwidthInMbps"]] = getECXL2ConnectionBandwidthInMB(ecx.IntValue(conn.Speed), ecx.StringValue(conn.SpeedUnit))
+	transformed[ecxL2ConnectionSchemaNames["Notifications"]] = conn.Notifications
+	transformed[ecxL2ConnectionSchemaNames["PurchaseOrderNumber"]] = conn.PurchaseOrderNumber
+	transformed[ecxL2ConnectionSchemaNames["NamedTag"]] = conn.NamedTag
+	transformed[ecxL2ConnectionSchemaNames["AdditionalInfo"]] = flattenECXL2ConnectionAdditionalInfo(conn.AdditionalInfo)
+	transformed[ecxL2ConnectionSchemaNames["ZSideVlan"]] = formatECXL2ConnectionVlan(conn.ZSideVlanSTag, conn.ZSideVlanCTag)
+	transformed[ecxL2ConnectionSchemaNames["LifecycleStage"]] = getECXL2ConnectionLifecycleStage(ecx.StringValue(conn.Status), ecx.StringValue(conn.ProviderStatus))
+	transformed[ecxL2ConnectionSchemaNames["BGPASN"]] = getECXL2ConnectionDetailsValue(conn, ecxL2ConnectionCloudDetailsKeys["ASN"])
+	transformed[ecxL2ConnectionSchemaNames["RequiredConfirmationData"]] = getECXL2ConnectionRequiredConfirmationData(conn)
+	transformed[ecxL2ConnectionSchemaNames["Actions"]] = flattenECXL2ConnectionActions(conn.Actions)
+	transformed[ecxL2ConnectionSchemaNames["CloudDetails"]] = flattenECXL2ConnectionCloudDetails(conn)
+	transformed[ecxL2ConnectionSchemaNames["Fingerprint"]] = getECXL2ConnectionFingerprint(conn)
+	return []interface{}{transformed}
+}
+
+func dataSourceECXL2ConnectionPairRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conf := m.(*Config)
+	var diags diag.Diagnostics
+	uuid := d.Get(ecxL2ConnectionPairSchemaNames["UUID"]).(string)
+	primary, secondary, err := getECXL2ConnectionPair(conf.ecx.GetL2Connection, uuid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(ecx.StringValue(primary.UUID))
+	if err := d.Set(ecxL2ConnectionPairSchemaNames["PrimaryUUID"], primary.UUID); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading PrimaryUUID: %s", err))
+	}
+	if err := d.Set(ecxL2ConnectionPairSchemaNames["Primary"], flattenECXL2ConnectionPairConnection(primary)); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Primary: %s", err))
+	}
+	var secondaryUUID *string
+	var secondaryConn interface{} = []interface{}{}
+	if secondary != nil {
+		secondaryUUID = secondary.UUID
+		secondaryConn = flattenECXL2ConnectionPairConnection(secondary)
+	}
+	if err := d.Set(ecxL2ConnectionPairSchemaNames["SecondaryUUID"], secondaryUUID); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading SecondaryUUID: %s", err))
+	}
+	if err := d.Set(ecxL2ConnectionPairSchemaNames["Secondary"], secondaryConn); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Secondary: %s", err))
+	}
+	return diags
+}
+
+type getL2Connection func(uuid string) (*ecx.L2Connection, error)
+
+//getECXL2ConnectionPair fetches connection with a given UUID along with its
+//redundant connection and returns them as primary and secondary connection
+//respectively. Connection with a given UUID is treated as primary unless
+//its redundancy type indicates otherwise
+func getECXL2ConnectionPair(fetchFunc getL2Connection, uuid string) (*ecx.L2Connection, *ecx.L2Connection, error) {
+	conn, redundant, err := getECXL2ConnectionWithRedundant(fetchFunc, uuid)
+	if err != nil || redundant == nil {
+		return conn, redundant, err
+	}
+	if !isECXL2ConnectionPrimary(conn) && isECXL2ConnectionPrimary(redundant) {
+		return redundant, conn, nil
+	}
+	return conn, redundant, nil
+}
+
+//getECXL2ConnectionWithRedundant fetches connection with a given UUID along
+//with its redundant connection, if any. Connections that are deleted or
+//being deleted are reported as an error
+func getECXL2ConnectionWithRedundant(fetchFunc getL2Connection, uuid string) (*ecx.L2Connection, *ecx.L2Connection, error) {
+	conn, err := fetchFunc(uuid)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot fetch connection %q due to %v", uuid, err)
+	}
+	if status := ecx.StringValue(conn.Status); isStringInSlice(status, ecxL2ConnectionRemovedStatuses) {
+		return nil, nil, fmt.Errorf("connection %q is in %s status and is no longer available", uuid, status)
+	}
+	redundantUUID := ecx.StringValue(conn.RedundantUUID)
+	if redundantUUID == "" {
+		return conn, nil, nil
+	}
+	redundant, err := fetchFunc(redundantUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot fetch redundant connection %q due to %v", redundantUUID, err)
+	}
+	return conn, redundant, nil
+}
@@ -0,0 +1,46 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const tstL2ConnectionUUIDEnvVar = "TF_ACC_ECX_L2_CONNECTION_UUID"
+
+func TestAccECXL2ConnectionPairDataSource(t *testing.T) {
+	t.Parallel()
+	connUUID, err := getFromEnv(tstL2ConnectionUUIDEnvVar)
+	if err != nil {
+		t.Skipf("skipping: %s", err)
+	}
+	context := map[string]interface{}{
+		"resourceName": "tf-pair",
+		"uuid":         connUUID,
+	}
+	resourceName := fmt.Sprintf("data.equinix_ecx_l2_connection_pair.%s", context["resourceName"].(string))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccECXL2ConnectionPairDataSource(context),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "uuid", connUUID),
+					resource.TestCheckResourceAttrSet(resourceName, "primary_uuid"),
+					resource.TestCheckResourceAttrSet(resourceName, "primary.0.name"),
+					resource.TestCheckResourceAttrSet(resourceName, "primary.0.status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccECXL2ConnectionPairDataSource(ctx map[string]interface{}) string {
+	return nprintf(`
+data "equinix_ecx_l2_connection_pair" "%{resourceName}" {
+  uuid = "%{uuid}"
+}
+`, ctx)
+}
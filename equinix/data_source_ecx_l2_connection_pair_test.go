@@ -0,0 +1,49 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricL2ConnectionPair_getPair(t *testing.T) {
+	//given
+	primary := &ecx.L2Connection{UUID: ecx.String(randString(36)), RedundancyType: ecx.String("PRIMARY")}
+	secondary := &ecx.L2Connection{UUID: ecx.String(randString(36)), RedundancyType: ecx.String("SECONDARY")}
+	primary.RedundantUUID = secondary.UUID
+	secondary.RedundantUUID = primary.UUID
+	single := &ecx.L2Connection{UUID: ecx.String(randString(36))}
+	deleted := &ecx.L2Connection{UUID: ecx.String(randString(36)), Status: ecx.String(ecx.ConnectionStatusDeprovisioned)}
+	conns := map[string]*ecx.L2Connection{
+		ecx.StringValue(primary.UUID):   primary,
+		ecx.StringValue(secondary.UUID): secondary,
+		ecx.StringValue(single.UUID):    single,
+		ecx.StringValue(deleted.UUID):   deleted,
+	}
+	fetchFunc := func(uuid string) (*ecx.L2Connection, error) {
+		if conn, ok := conns[uuid]; ok {
+			return conn, nil
+		}
+		return nil, fmt.Errorf("connection %q not found", uuid)
+	}
+	//when
+	byPrimaryFirst, byPrimarySecond, byPrimaryErr := getECXL2ConnectionPair(fetchFunc, ecx.StringValue(primary.UUID))
+	bySecondaryFirst, bySecondarySecond, bySecondaryErr := getECXL2ConnectionPair(fetchFunc, ecx.StringValue(secondary.UUID))
+	singleFirst, singleSecond, singleErr := getECXL2ConnectionPair(fetchFunc, ecx.StringValue(single.UUID))
+	_, _, unknownErr := getECXL2ConnectionPair(fetchFunc, randString(36))
+	_, _, deletedErr := getECXL2ConnectionPair(fetchFunc, ecx.StringValue(deleted.UUID))
+	//then
+	assert.Nil(t, byPrimaryErr, "Pair resolved by primary UUID without error")
+	assert.Equal(t, primary, byPrimaryFirst, "Primary connection resolved by primary UUID")
+	assert.Equal(t, secondary, byPrimarySecond, "Secondary connection resolved by primary UUID")
+	assert.Nil(t, bySecondaryErr, "Pair resolved by secondary UUID without error")
+	assert.Equal(t, primary, bySecondaryFirst, "Primary connection resolved by secondary UUID")
+	assert.Equal(t, secondary, bySecondarySecond, "Secondary connection resolved by secondary UUID")
+	assert.Nil(t, singleErr, "Non redundant connection resolved without error")
+	assert.Equal(t, single, singleFirst, "Non redundant connection is resolved as primary")
+	assert.Nil(t, singleSecond, "Non redundant connection has no secondary")
+	assert.NotNil(t, unknownErr, "Unknown connection fails to resolve")
+	assert.NotNil(t, deletedErr, "Deprovisioned connection fails to resolve")
+}
@@ -0,0 +1,85 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/equinix/ecx-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFabricL2ConnectionDataSource_getWithRedundant(t *testing.T) {
+	//given
+	primary := &ecx.L2Connection{UUID: ecx.String(randString(36)), Status: ecx.String(ecx.ConnectionStatusProvisioned)}
+	secondary := &ecx.L2Connection{UUID: ecx.String(randString(36)), Status: ecx.String(ecx.ConnectionStatusProvisioned)}
+	primary.RedundantUUID = secondary.UUID
+	deleted := &ecx.L2Connection{UUID: ecx.String(randString(36)), Status: ecx.String(ecx.ConnectionStatusDeprovisioned)}
+	conns := map[string]*ecx.L2Connection{
+		ecx.StringValue(primary.UUID):   primary,
+		ecx.StringValue(secondary.UUID): secondary,
+		ecx.StringValue(deleted.UUID):   deleted,
+	}
+	fetchFunc := func(uuid string) (*ecx.L2Connection, error) {
+		if conn, ok := conns[uuid]; ok {
+			return conn, nil
+		}
+		return nil, fmt.Errorf("connection %q not found", uuid)
+	}
+	//when
+	first, second, err := getECXL2ConnectionWithRedundant(fetchFunc, ecx.StringValue(primary.UUID))
+	_, _, deletedErr := getECXL2ConnectionWithRedundant(fetchFunc, ecx.StringValue(deleted.UUID))
+	_, _, unknownErr := getECXL2ConnectionWithRedundant(fetchFunc, randString(36))
+	//then
+	assert.Nil(t, err, "Connection fetched without error")
+	assert.Equal(t, primary, first, "Connection matches")
+	assert.Equal(t, secondary, second, "Redundant connection matches")
+	assert.NotNil(t, deletedErr, "Deleted connection fetch returns error")
+	assert.NotNil(t, unknownErr, "Unknown connection fetch returns error")
+}
+
+func TestFabricL2ConnectionDataSource_updateResourceData(t *testing.T) {
+	//given
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionDataSourceSchema(), make(map[string]interface{}))
+	primary := &ecx.L2Connection{
+		UUID:                ecx.String(randString(36)),
+		Name:                ecx.String(randString(36)),
+		Speed:               ecx.Int(50),
+		SpeedUnit:           ecx.String("MB"),
+		Status:              ecx.String(ecx.ConnectionStatusProvisioned),
+		PortUUID:            ecx.String(randString(36)),
+		ZSidePortUUID:       ecx.String(randString(36)),
+		VlanSTag:            ecx.Int(100),
+		ZSideVlanSTag:       ecx.Int(200),
+		RedundantUUID:       ecx.String(randString(36)),
+		RedundancyType:      ecx.String("PRIMARY"),
+		Notifications:       []string{"john@equinix.com"},
+		PurchaseOrderNumber: ecx.String(randString(10)),
+	}
+	secondary := &ecx.L2Connection{
+		UUID:           primary.RedundantUUID,
+		Name:           ecx.String(randString(36)),
+		RedundancyType: ecx.String("SECONDARY"),
+		VlanSTag:       ecx.Int(101),
+		VlanCTag:       ecx.Int(201),
+	}
+	//when
+	err := updateECXL2ConnectionDataSource(primary, secondary, d)
+	//then
+	assert.Nil(t, err, "Update of resource data does not return error")
+	assert.Equal(t, ecx.StringValue(primary.Name), d.Get(ecxL2ConnectionSchemaNames["Name"]), "Name matches")
+	assert.Equal(t, ecx.IntValue(primary.Speed), d.Get(ecxL2ConnectionSchemaNames["Speed"]), "Speed matches")
+	assert.Equal(t, ecx.StringValue(primary.Status), d.Get(ecxL2ConnectionSchemaNames["Status"]), "Status matches")
+	assert.Equal(t, ecx.StringValue(primary.ZSidePortUUID), d.Get(ecxL2ConnectionSchemaNames["ZSidePortUUID"]), "ZSidePortUUID matches")
+	assert.Equal(t, ecx.IntValue(primary.ZSideVlanSTag), d.Get(ecxL2ConnectionSchemaNames["ZSideVlanSTag"]), "ZSideVlanSTag matches")
+	assert.Equal(t, ecx.StringValue(primary.RedundantUUID), d.Get(ecxL2ConnectionSchemaNames["RedundantUUID"]), "RedundantUUID matches")
+	assert.Equal(t, true, d.Get(ecxL2ConnectionSchemaNames["IsPrimary"]), "IsPrimary matches")
+	secondaryPrefix := ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0."
+	assert.Equal(t, ecx.StringValue(secondary.UUID), d.Get(secondaryPrefix+ecxL2ConnectionSchemaNames["UUID"]), "Secondary UUID matches")
+	assert.Equal(t, ecx.StringValue(secondary.Name), d.Get(secondaryPrefix+ecxL2ConnectionSchemaNames["Name"]), "Secondary Name matches")
+	assert.Equal(t, primary.Notifications, expandSetToStringList(d.Get(ecxL2ConnectionSchemaNames["Notifications"]).(*schema.Set)), "Notifications match")
+	assert.Equal(t, ecx.StringValue(primary.PurchaseOrderNumber), d.Get(ecxL2ConnectionSchemaNames["PurchaseOrderNumber"]), "PurchaseOrderNumber matches")
+	assert.Equal(t, "50 MB", d.Get(ecxL2ConnectionSchemaNames["SpeedHuman"]), "SpeedHuman matches")
+	assert.Equal(t, ecx.IntValue(secondary.VlanSTag), d.Get(secondaryPrefix+ecxL2ConnectionSchemaNames["VlanSTag"]), "Secondary VlanSTag matches")
+	assert.Equal(t, ecx.IntValue(secondary.VlanCTag), d.Get(secondaryPrefix+ecxL2ConnectionSchemaNames["VlanCTag"]), "Secondary VlanCTag matches")
+}
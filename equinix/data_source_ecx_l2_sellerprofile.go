@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/equinix/ecx-go/v2"
@@ -26,6 +27,7 @@ var ecxL2SellerProfileSchemaNames = map[string]string{
 	"OrganizationName":   "organization_name",
 	"SpeedBand":          "speed_band",
 	"Metros":             "metro",
+	"MetroCodes":         "metro_codes",
 	"AdditionalInfos":    "additional_info",
 }
 
@@ -41,6 +43,7 @@ var ecxL2SellerProfileDescriptions = map[string]string{
 	"OrganizationName":   "Name of seller's organization",
 	"SpeedBand":          "One or more specifications of speed/bandwidth supported by given seller profile",
 	"Metros":             "One or more specifications of metro locations supported by seller profile",
+	"MetroCodes":         "Codes of metro locations supported by seller profile",
 	"AdditionalInfos":    "One or more specifications of additional buyer information attributes that can be provided in connection definition that uses given seller profile",
 }
 
@@ -78,7 +81,7 @@ func dataSourceECXL2SellerProfile() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceECXL2SellerProfileRead,
 		Description: "Use this data source to get details of Equinix Fabric layer 2	seller profile with a given name and / or organization",
-		Schema: createECXL2SellerProfileSchema(),
+		Schema:      createECXL2SellerProfileSchema(),
 	}
 }
 
@@ -155,6 +158,14 @@ func createECXL2SellerProfileSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		ecxL2SellerProfileSchemaNames["MetroCodes"]: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: ecxL2SellerProfileDescriptions["MetroCodes"],
+		},
 		ecxL2SellerProfileSchemaNames["Metros"]: {
 			Type:        schema.TypeSet,
 			Computed:    true,
@@ -235,7 +246,7 @@ func dataSourceECXL2SellerProfileRead(ctx context.Context, d *schema.ResourceDat
 	name := d.Get(ecxL2SellerProfileSchemaNames["Name"]).(string)
 	orgName := d.Get(ecxL2SellerProfileSchemaNames["OrganizationName"]).(string)
 	orgGlobalName := d.Get(ecxL2SellerProfileSchemaNames["GlobalOrganization"]).(string)
-	profiles, err := conf.ecx.GetL2SellerProfiles()
+	profiles, err := conf.getL2SellerProfiles()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -309,6 +320,9 @@ func updateECXL2SellerProfileResource(profile ecx.L2ServiceProfile, d *schema.Re
 	if err := d.Set(ecxL2SellerProfileSchemaNames["Metros"], flattenECXL2SellerProfileMetros(profile.Metros)); err != nil {
 		return fmt.Errorf("error reading Metros: %s", err)
 	}
+	if err := d.Set(ecxL2SellerProfileSchemaNames["MetroCodes"], getECXL2SellerProfileMetroCodes(profile.Metros)); err != nil {
+		return fmt.Errorf("error reading MetroCodes: %s", err)
+	}
 	if err := d.Set(ecxL2SellerProfileSchemaNames["AdditionalInfos"], flattenECXL2SellerProfileAdditionalInfos(profile.AdditionalInfos)); err != nil {
 		return fmt.Errorf("error reading AdditionalInfos: %s", err)
 	}
@@ -328,6 +342,18 @@ func flattenECXL2SellerProfileMetros(metros []ecx.L2SellerProfileMetro) interfac
 	return transformed
 }
 
+//getECXL2SellerProfileMetroCodes returns sorted codes of given metros
+func getECXL2SellerProfileMetroCodes(metros []ecx.L2SellerProfileMetro) []string {
+	codes := make([]string, 0, len(metros))
+	for _, metro := range metros {
+		if code := ecx.StringValue(metro.Code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
 func flattenECXL2SellerProfileAdditionalInfos(infos []ecx.L2SellerProfileAdditionalInfo) interface{} {
 	transformed := make([]interface{}, len(infos))
 	for i := range infos {
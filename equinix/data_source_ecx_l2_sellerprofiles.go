@@ -89,7 +89,7 @@ func dataSourceECXL2SellerProfiles() *schema.Resource {
 func dataSourceECXL2SellerProfilesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
 	var diags diag.Diagnostics
-	profiles, err := conf.ecx.GetL2SellerProfiles()
+	profiles, err := conf.getL2SellerProfiles()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -173,6 +173,7 @@ func flattenECXL2SellerProfiles(profiles []ecx.L2ServiceProfile) interface{} {
 		transformedProfile[ecxL2SellerProfileSchemaNames["OrganizationName"]] = profiles[i].OrganizationName
 		transformedProfile[ecxL2SellerProfileSchemaNames["SpeedBand"]] = flattenECXL2ServiceProfileSpeedBands(profiles[i].SpeedBands)
 		transformedProfile[ecxL2SellerProfileSchemaNames["Metros"]] = flattenECXL2SellerProfileMetros(profiles[i].Metros)
+		transformedProfile[ecxL2SellerProfileSchemaNames["MetroCodes"]] = getECXL2SellerProfileMetroCodes(profiles[i].Metros)
 		transformedProfile[ecxL2SellerProfileSchemaNames["AdditionalInfos"]] = flattenECXL2SellerProfileAdditionalInfos(profiles[i].AdditionalInfos)
 		transformed[i] = transformedProfile
 	}
@@ -0,0 +1,81 @@
+package equinix
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/equinix/ne-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const neDeviceInterfaceStatusAvailable = "AVAILABLE"
+
+var networkDeviceInterfacesSchemaNames = map[string]string{
+	"DeviceUUID": "device_uuid",
+	"Interfaces": "interfaces",
+	"InUse":      "in_use",
+}
+
+var networkDeviceInterfacesDescriptions = map[string]string{
+	"DeviceUUID": "Unique identifier of a Network Edge virtual device",
+	"Interfaces": "List of network interfaces of a given device",
+	"InUse":      "Indicates if interface is not available for new connections",
+}
+
+func dataSourceNetworkDeviceInterfaces() *schema.Resource {
+	interfaceSchema := createNetworkDeviceInterfaceSchema()
+	interfaceSchema[networkDeviceInterfacesSchemaNames["InUse"]] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: networkDeviceInterfacesDescriptions["InUse"],
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkDeviceInterfacesRead,
+		Description: "Use this data source to list network interfaces of a given Network Edge virtual device",
+		Schema: map[string]*schema.Schema{
+			networkDeviceInterfacesSchemaNames["DeviceUUID"]: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  networkDeviceInterfacesDescriptions["DeviceUUID"],
+			},
+			networkDeviceInterfacesSchemaNames["Interfaces"]: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: interfaceSchema,
+				},
+				Description: networkDeviceInterfacesDescriptions["Interfaces"],
+			},
+		},
+	}
+}
+
+func dataSourceNetworkDeviceInterfacesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conf := m.(*Config)
+	var diags diag.Diagnostics
+	uuid := d.Get(networkDeviceInterfacesSchemaNames["DeviceUUID"]).(string)
+	device, err := conf.ne.GetDevice(uuid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(uuid)
+	if err := d.Set(networkDeviceInterfacesSchemaNames["Interfaces"], flattenNetworkDeviceInterfacesWithUsage(device.Interfaces)); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Interfaces: %s", err))
+	}
+	return diags
+}
+
+func flattenNetworkDeviceInterfacesWithUsage(interfaces []ne.DeviceInterface) interface{} {
+	transformed := flattenNetworkDeviceInterfaces(interfaces).([]interface{})
+	for i := range interfaces {
+		transformed[i].(map[string]interface{})[networkDeviceInterfacesSchemaNames["InUse"]] = isNetworkDeviceInterfaceInUse(interfaces[i])
+	}
+	return transformed
+}
+
+func isNetworkDeviceInterfaceInUse(iface ne.DeviceInterface) bool {
+	return ne.StringValue(iface.Status) != neDeviceInterfaceStatusAvailable
+}
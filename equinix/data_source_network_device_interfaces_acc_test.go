@@ -0,0 +1,47 @@
+package equinix
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const networkDeviceUUIDEnvVar = "TF_ACC_NETWORK_DEVICE_UUID"
+
+func TestAccNetworkDeviceInterfacesDataSource(t *testing.T) {
+	t.Parallel()
+	deviceUUID, err := getFromEnv(networkDeviceUUIDEnvVar)
+	if err != nil {
+		t.Skipf("skipping: %s", err)
+	}
+	context := map[string]interface{}{
+		"resourceName": "tf-interfaces",
+		"device_uuid":  deviceUUID,
+	}
+	resourceName := fmt.Sprintf("data.equinix_network_device_interfaces.%s", context["resourceName"].(string))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkDeviceInterfacesDataSource(context),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "device_uuid", deviceUUID),
+					resource.TestCheckResourceAttrSet(resourceName, "interfaces.0.id"),
+					resource.TestCheckResourceAttrSet(resourceName, "interfaces.0.name"),
+					resource.TestCheckResourceAttrSet(resourceName, "interfaces.0.status"),
+					resource.TestCheckResourceAttrSet(resourceName, "interfaces.0.in_use"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNetworkDeviceInterfacesDataSource(ctx map[string]interface{}) string {
+	return nprintf(`
+data "equinix_network_device_interfaces" "%{resourceName}" {
+  device_uuid = "%{device_uuid}"
+}
+`, ctx)
+}
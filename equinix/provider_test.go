@@ -1,6 +1,7 @@
 package equinix
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/equinix/rest-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform/helper/hashcode"
 	"github.com/stretchr/testify/assert"
@@ -108,6 +110,19 @@ func TestProvider_stringsFound_negative(t *testing.T) {
 	assert.False(t, result, "Given strings were found")
 }
 
+func TestProvider_validateNotificationDomains(t *testing.T) {
+	//given
+	allowed := []string{"equinix.com", "example.com"}
+	//when
+	allowedErr := validateNotificationDomains([]string{"john@equinix.com", "marry@Example.com"}, allowed)
+	disallowedErr := validateNotificationDomains([]string{"john@equinix.com", "marry@gmail.com"}, allowed)
+	unrestrictedErr := validateNotificationDomains([]string{"marry@gmail.com"}, nil)
+	//then
+	assert.Nil(t, allowedErr, "Emails from allowed domains pass validation")
+	assert.NotNil(t, disallowedErr, "Email from disallowed domain fails validation")
+	assert.Nil(t, unrestrictedErr, "Emails pass validation when no domains are configured")
+}
+
 func TestProvider_resourceDataChangedKeys(t *testing.T) {
 	//given
 	keys := []string{"key", "keyTwo", "keyThree"}
@@ -284,6 +299,185 @@ func TestProvider_isRestNotFoundError(t *testing.T) {
 	assert.Equal(t, expected, result, "Result matches expected output")
 }
 
+func TestProvider_parseTimeoutDuration(t *testing.T) {
+	//given
+	input := []string{"30", " 90 ", "2m", "1m30s", "0", "-5", "5x", ""}
+	expected := []time.Duration{30 * time.Second, 90 * time.Second, 2 * time.Minute, 90 * time.Second, 0, 0, 0, 0}
+	//when
+	result := make([]time.Duration, len(input))
+	errs := make([]error, len(input))
+	for i := range input {
+		result[i], errs[i] = parseTimeoutDuration(input[i])
+	}
+	//then
+	assert.Equal(t, expected, result, "Parsed timeouts match")
+	for i := range input {
+		assert.Equal(t, expected[i] == 0, errs[i] != nil, "Timeout %q parse result matches", input[i])
+	}
+}
+
+func TestProvider_restErrorDiagnostics(t *testing.T) {
+	//given
+	restErr := rest.Error{
+		HTTPCode: http.StatusBadRequest,
+		Message:  "Bad Request",
+		ApplicationErrors: []rest.ApplicationError{
+			{Code: "IC-LAYER2-4021", Message: "Invalid VLAN", Property: "vlanSTag", AdditionalInfo: "correlationId: abc-123"},
+		},
+	}
+	wrapped := fmt.Errorf("error updating connection %q: %w", "abc", restErr)
+	//when
+	verboseDiags := restErrorDiagnostics(wrapped, true)
+	defaultDiags := restErrorDiagnostics(wrapped, false)
+	otherDiags := restErrorDiagnostics(fmt.Errorf("some bogus error"), true)
+	//then
+	assert.Len(t, verboseDiags, 1, "Verbose diagnostics have single entry")
+	assert.Equal(t, wrapped.Error(), verboseDiags[0].Summary, "Verbose diagnostic summary matches error")
+	assert.Contains(t, verboseDiags[0].Detail, "HTTP status code: 400", "Verbose diagnostic detail contains HTTP status code")
+	assert.Contains(t, verboseDiags[0].Detail, `code="IC-LAYER2-4021"`, "Verbose diagnostic detail contains application error code")
+	assert.Contains(t, verboseDiags[0].Detail, "correlationId: abc-123", "Verbose diagnostic detail contains additional info")
+	assert.Empty(t, defaultDiags[0].Detail, "Diagnostic detail is empty when verbose errors are disabled")
+	assert.Empty(t, otherDiags[0].Detail, "Diagnostic detail is empty for non REST errors")
+}
+
+func TestProvider_isRestRateLimitError(t *testing.T) {
+	//given
+	input := []error{
+		rest.Error{HTTPCode: http.StatusTooManyRequests, Message: "Too Many Requests"},
+		rest.Error{HTTPCode: http.StatusNotFound, Message: "Not Found"},
+		fmt.Errorf("some bogus error"),
+	}
+	expected := []bool{
+		true,
+		false,
+		false,
+	}
+	//when
+	result := make([]bool, len(input))
+	for i := range input {
+		result[i] = isRestRateLimitError(input[i])
+	}
+	//then
+	assert.Equal(t, expected, result, "Result matches expected output")
+}
+
+func TestProvider_rateLimitAwareRefreshFunc(t *testing.T) {
+	//given
+	responses := []struct {
+		state string
+		err   error
+	}{
+		{"PROVISIONING", nil},
+		{"", rest.Error{HTTPCode: http.StatusTooManyRequests}},
+		{"PROVISIONED", nil},
+	}
+	calls := 0
+	retryAfterCalls := 0
+	refresh := func() (interface{}, string, error) {
+		resp := responses[calls]
+		calls++
+		if resp.err != nil {
+			return nil, "", resp.err
+		}
+		return resp.state, resp.state, nil
+	}
+	retryAfter := func() time.Duration {
+		retryAfterCalls++
+		return time.Millisecond
+	}
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"PROVISIONING"},
+		Target:     []string{"PROVISIONED"},
+		Timeout:    time.Second,
+		MinTimeout: time.Millisecond,
+		Refresh:    rateLimitAwareRefreshFunc(context.Background(), refresh, retryAfter),
+	}
+	//when
+	result, err := stateConf.WaitForStateContext(context.Background())
+	//then
+	assert.Nil(t, err, "Wait does not fail on rate limit error")
+	assert.Equal(t, "PROVISIONED", result, "Wait result matches target state")
+	assert.Equal(t, len(responses), calls, "Refresh function was called expected number of times")
+	assert.Equal(t, 1, retryAfterCalls, "Retry after was consulted once")
+}
+
+func TestProvider_requestTimeoutRefreshFunc(t *testing.T) {
+	//given
+	calls := 0
+	refresh := func(ctx context.Context) (interface{}, string, error) {
+		calls++
+		if calls == 2 {
+			<-ctx.Done()
+			return nil, "", ctx.Err()
+		}
+		return "PROVISIONING", "PROVISIONING", nil
+	}
+	wrapped := requestTimeoutRefreshFunc(context.Background(), refresh, 10*time.Millisecond)
+	//when
+	_, firstState, firstErr := wrapped()
+	_, secondState, secondErr := wrapped()
+	//then
+	assert.Nil(t, firstErr, "First refresh does not return error")
+	assert.Equal(t, "PROVISIONING", firstState, "First refresh returns state")
+	assert.Nil(t, secondErr, "Timed out refresh does not return error")
+	assert.Equal(t, "PROVISIONING", secondState, "Timed out refresh returns last known state")
+	assert.Equal(t, 2, calls, "Refresh function was called synchronously twice")
+}
+
+func TestProvider_requestTimeoutRefreshFunc_noPreviousState(t *testing.T) {
+	//given
+	refresh := func(ctx context.Context) (interface{}, string, error) {
+		<-ctx.Done()
+		return nil, "", ctx.Err()
+	}
+	wrapped := requestTimeoutRefreshFunc(context.Background(), refresh, 10*time.Millisecond)
+	//when
+	result, state, err := wrapped()
+	//then
+	assert.Nil(t, err, "Timed out refresh without previous state does not return error")
+	assert.NotNil(t, result, "Timed out refresh without previous state returns result")
+	assert.Equal(t, statusCheckTimedOutState, state, "Timed out refresh without previous state returns pending state")
+}
+
+func TestProvider_requestTimeoutRefreshFunc_responseError(t *testing.T) {
+	//given
+	refresh := func(ctx context.Context) (interface{}, string, error) {
+		<-ctx.Done()
+		return nil, "", rest.Error{HTTPCode: http.StatusInternalServerError}
+	}
+	wrapped := requestTimeoutRefreshFunc(context.Background(), refresh, 10*time.Millisecond)
+	//when
+	_, _, err := wrapped()
+	//then
+	assert.NotNil(t, err, "Refresh failed with API response error returns error")
+}
+
+func TestProvider_waitForStateContextWithStats(t *testing.T) {
+	//given
+	calls := 0
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"PROVISIONING"},
+		Target:       []string{"PROVISIONED"},
+		Timeout:      time.Second,
+		MinTimeout:   time.Millisecond,
+		PollInterval: time.Millisecond,
+		Refresh: func() (interface{}, string, error) {
+			calls++
+			if calls < 3 {
+				return "PROVISIONING", "PROVISIONING", nil
+			}
+			return "PROVISIONED", "PROVISIONED", nil
+		},
+	}
+	//when
+	result, stats, err := waitForStateContextWithStats(context.Background(), stateConf, "test resource")
+	//then
+	assert.Nil(t, err, "Wait does not return error")
+	assert.Equal(t, "PROVISIONED", result, "Wait returns target result")
+	assert.Equal(t, 3, stats.polls, "Number of status checks is recorded")
+	assert.Greater(t, int64(stats.duration), int64(0), "Wait duration is recorded")
+}
+
 func TestProvider_schemaSetToMap(t *testing.T) {
 	//given
 	type item struct {
@@ -1,76 +1,353 @@
 package equinix
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/equinix/ecx-go/v2"
+	"github.com/equinix/ne-go"
 	"github.com/equinix/rest-go"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var ecxL2ConnectionSchemaNames = map[string]string{
-	"UUID":                "uuid",
-	"Name":                "name",
-	"ProfileUUID":         "profile_uuid",
-	"Speed":               "speed",
-	"SpeedUnit":           "speed_unit",
-	"Status":              "status",
-	"ProviderStatus":      "provider_status",
-	"Notifications":       "notifications",
-	"PurchaseOrderNumber": "purchase_order_number",
-	"PortUUID":            "port_uuid",
-	"DeviceUUID":          "device_uuid",
-	"DeviceInterfaceID":   "device_interface_id",
-	"VlanSTag":            "vlan_stag",
-	"VlanCTag":            "vlan_ctag",
-	"NamedTag":            "named_tag",
-	"AdditionalInfo":      "additional_info",
-	"ZSidePortUUID":       "zside_port_uuid",
-	"ZSideVlanSTag":       "zside_vlan_stag",
-	"ZSideVlanCTag":       "zside_vlan_ctag",
-	"SellerRegion":        "seller_region",
-	"SellerMetroCode":     "seller_metro_code",
-	"AuthorizationKey":    "authorization_key",
-	"RedundantUUID":       "redundant_uuid",
-	"RedundancyType":      "redundancy_type",
-	"SecondaryConnection": "secondary_connection",
+	"UUID":                        "uuid",
+	"Name":                        "name",
+	"ProfileUUID":                 "profile_uuid",
+	"Speed":                       "speed",
+	"SpeedUnit":                   "speed_unit",
+	"SpeedHuman":                  "speed_human",
+	"BandwidthInMbps":             "bandwidth_in_mbps",
+	"Status":                      "status",
+	"ProviderStatus":              "provider_status",
+	"ProviderStatusHistory":       "provider_status_history",
+	"Notifications":               "notifications",
+	"PurchaseOrderNumber":         "purchase_order_number",
+	"PortUUID":                    "port_uuid",
+	"DeviceUUID":                  "device_uuid",
+	"DeviceInterfaceID":           "device_interface_id",
+	"VlanSTag":                    "vlan_stag",
+	"VlanCTag":                    "vlan_ctag",
+	"NamedTag":                    "named_tag",
+	"AdditionalInfo":              "additional_info",
+	"ZSidePortUUID":               "zside_port_uuid",
+	"ZSideServiceKey":             "zside_service_key",
+	"PeeringPrefixes":             "peering_prefixes",
+	"AdvertisedRoutes":            "advertised_routes",
+	"ZSideVlanSTag":               "zside_vlan_stag",
+	"ZSideVlanCTag":               "zside_vlan_ctag",
+	"ZSideVlan":                   "zside_vlan",
+	"SellerRegion":                "seller_region",
+	"SellerMetroCode":             "seller_metro_code",
+	"AuthorizationKey":            "authorization_key",
+	"RedundantUUID":               "redundant_uuid",
+	"RedundancyType":              "redundancy_type",
+	"SecondaryConnection":         "secondary_connection",
+	"CloudDetails":                "cloud_details",
+	"PollRequestTimeout":          "poll_request_timeout",
+	"RequestTimeout":              "request_timeout",
+	"InitialPollDelay":            "initial_poll_delay",
+	"CreateTargetStatuses":        "create_target_statuses",
+	"DrainBeforeDelete":           "drain_before_delete",
+	"RequireUniqueName":           "require_unique_name",
+	"VlanUniquenessScope":         "vlan_uniqueness_scope",
+	"AllowSellerSpeedIncrease":    "allow_seller_speed_increase",
+	"BGPASN":                      "bgp_asn",
+	"RequiredConfirmationData":    "required_confirmation_data",
+	"Actions":                     "actions",
+	"LifecycleStage":              "lifecycle_stage",
+	"SkipReadAfterCreate":         "skip_read_after_create",
+	"WaitForProviderStatus":       "wait_for_provider_status",
+	"AcknowledgeDisruptiveChange": "acknowledge_disruptive_change",
+	"IsRemote":                    "is_remote",
+	"SellerOrganizationName":      "seller_organization_name",
+	"Profile":                     "profile",
+	"Fingerprint":                 "fingerprint",
+	"IsPrimary":                   "is_primary",
+	"StatusChangedAt":             "status_changed_at",
+	"SecondaryUUID":               "secondary_uuid",
+	"SecondaryStatus":             "secondary_status",
+	"SecondaryProviderStatus":     "secondary_provider_status",
 }
 
 var ecxL2ConnectionDescriptions = map[string]string{
-	"UUID":                "Unique identifier of the connection",
-	"Name":                "Connection name. An alpha-numeric 24 characters string which can include only hyphens and underscores",
-	"ProfileUUID":         "Unique identifier of the service provider's service profile",
-	"Speed":               "Speed/Bandwidth to be allocated to the connection",
-	"SpeedUnit":           "Unit of the speed/bandwidth to be allocated to the connection",
-	"Status":              "Connection provisioning status on Equinix Fabric side",
-	"ProviderStatus":      "Connection provisioning status on service provider's side",
-	"Notifications":       "A list of email addresses used for sending connection update notifications",
-	"PurchaseOrderNumber": "Connection's purchase order number to reflect on the invoice",
-	"PortUUID":            "Unique identifier of the buyer's port from which the connection would originate",
-	"DeviceUUID":          "Unique identifier of the Network Edge virtual device from which the connection would originate",
-	"DeviceInterfaceID":   "Identifier of network interface on a given device, used for a connection. If not specified then first available interface will be selected",
-	"VlanSTag":            "S-Tag/Outer-Tag of the connection, a numeric character ranging from 2 - 4094",
-	"VlanCTag":            "C-Tag/Inner-Tag of the connection, a numeric character ranging from 2 - 4094",
-	"NamedTag":            "The type of peering to set up in case when connecting to Azure Express Route. One of Public, Private, Microsoft, Manual",
-	"AdditionalInfo":      "One or more additional information key-value objects",
-	"ZSidePortUUID":       "Unique identifier of the port on the remote side (z-side)",
-	"ZSideVlanSTag":       "S-Tag/Outer-Tag of the connection on the remote side (z-side)",
-	"ZSideVlanCTag":       "C-Tag/Inner-Tag of the connection on the remote side (z-side)",
-	"SellerRegion":        "The region in which the seller port resides",
-	"SellerMetroCode":     "The metro code that denotes the connection’s remote side (z-side)",
-	"AuthorizationKey":    "Text field used to authorize connection on the provider side. Value depends on a provider service profile used for connection",
-	"RedundantUUID":       "Unique identifier of the redundant connection, applicable for HA connections",
-	"RedundancyType":      "Connection redundancy type, applicable for HA connections. Either primary or secondary",
-	"SecondaryConnection": "Definition of secondary connection for redundant, HA connectivity",
+	"UUID":                        "Unique identifier of the connection",
+	"Name":                        "Connection name. An alpha-numeric 24 characters string which can include only hyphens and underscores",
+	"ProfileUUID":                 "Unique identifier of the service provider's service profile",
+	"Speed":                       "Speed/Bandwidth to be allocated to the connection. When not set, speed of the only speed band supported by service profile is used",
+	"SpeedUnit":                   "Unit of the speed/bandwidth to be allocated to the connection. When not set, unit of the only speed band supported by service profile is used",
+	"SpeedHuman":                  "Human readable representation of connection speed/bandwidth along with its unit, i.e. 10 GB",
+	"BandwidthInMbps":             "Connection speed/bandwidth normalized to megabits per second, regardless of its unit",
+	"Status":                      "Connection provisioning status on Equinix Fabric side",
+	"ProviderStatus":              "Connection provisioning status on service provider's side",
+	"ProviderStatusHistory":       "Most recent provider status changes sampled on refresh, oldest first. Changes between refreshes are not captured",
+	"Notifications":               "A list of email addresses used for sending connection update notifications",
+	"PurchaseOrderNumber":         "Connection's purchase order number to reflect on the invoice",
+	"PortUUID":                    "Unique identifier of the buyer's port from which the connection would originate",
+	"DeviceUUID":                  "Unique identifier of the Network Edge virtual device from which the connection would originate",
+	"DeviceInterfaceID":           "Identifier of network interface on a given device, used for a connection. If not specified then first available interface will be selected",
+	"VlanSTag":                    "S-Tag/Outer-Tag of the connection, a numeric character ranging from 2 - 4094",
+	"VlanCTag":                    "C-Tag/Inner-Tag of the connection, a numeric character ranging from 2 - 4094. Value of 0 denotes untagged connection",
+	"NamedTag":                    "The type of peering to set up in case when connecting to Azure Express Route. One of Public, Private, Microsoft, Manual",
+	"AdditionalInfo":              "One or more additional information key-value objects",
+	"ZSidePortUUID":               "Unique identifier of the port on the remote side (z-side)",
+	"ZSideServiceKey":             "Service key of the remote side (z-side), i.e. Azure ExpressRoute circuit service key. Applicable only for Manual named tag",
+	"PeeringPrefixes":             "List of public IP prefixes, in CIDR notation, used for Azure Public or Microsoft peering. Applicable only for Public and Microsoft named tags",
+	"AdvertisedRoutes":            "List of routes, in CIDR notation, advertised over Azure Public or Microsoft peering. Applicable only for Public and Microsoft named tags",
+	"ZSideVlanSTag":               "S-Tag/Outer-Tag of the connection on the remote side (z-side)",
+	"ZSideVlanCTag":               "C-Tag/Inner-Tag of the connection on the remote side (z-side)",
+	"ZSideVlan":                   "Tagging of the connection on the remote side (z-side), formatted as S-Tag or S-Tag.C-Tag for QinQ ports",
+	"SellerRegion":                "The region in which the seller port resides",
+	"SellerMetroCode":             "The metro code that denotes the connection’s remote side (z-side)",
+	"AuthorizationKey":            "Text field used to authorize connection on the provider side. Value depends on a provider service profile used for connection",
+	"RedundantUUID":               "Unique identifier of the redundant connection, applicable for HA connections",
+	"RedundancyType":              "Connection redundancy type, applicable for HA connections. Either primary or secondary",
+	"SecondaryConnection":         "Definition of secondary connection for redundant, HA connectivity",
+	"CloudDetails":                "Cloud provider specific connection details reported by the platform, i.e. VLAN or BGP ASN",
+	"BGPASN":                      "BGP Autonomous System Number associated with a cloud connection, as assigned or reported by the platform",
+	"RequiredConfirmationData":    "Data required to confirm the connection on service provider's side, i.e. by connection accepter, keyed by data key",
+	"Actions":                     "Actions that have to be completed, i.e. on service provider's side, to progress connection provisioning",
+	"LifecycleStage":              "Coarse connection lifecycle stage derived from status and provider status. One of provisioning, active, needs-action, deprovisioning, deprovisioned or unknown",
+	"SkipReadAfterCreate":         "Populate state using connection details obtained while waiting for creation to complete instead of reading the connection again. Some computed attributes may be stale until next refresh",
+	"WaitForProviderStatus":       "Makes create operation wait until connection, along with its secondary connection, is provisioned on service provider's side",
+	"AcknowledgeDisruptiveChange": "Acknowledges that speed change of a connection to a service profile with speed driven by service provider's API may briefly interrupt the service. Such changes are blocked unless acknowledged",
+	"PollRequestTimeout":          "The duration of time, in seconds, after which a single connection status request made while waiting for create or delete to complete is canceled and retried",
+	"RequestTimeout":              "The duration of time, in seconds, that API requests made for this connection wait before being canceled, overriding provider's request_timeout",
+	"InitialPollDelay":            "The duration of time, in seconds, to wait after connection is requested before its status is checked for the first time",
+	"CreateTargetStatuses":        "Connection statuses that complete create operation, replacing default ones. Intended for service profiles with nonstandard provisioning flow",
+	"DrainBeforeDelete":           "The duration of time, in seconds, to wait before connection removal is requested, allowing traffic to be drained",
+	"RequireUniqueName":           "Enables verification, before connection is created, that there is no other connection with the same name",
+	"VlanUniquenessScope":         "Scope in which VLAN tags of port connection have to be unique, verified before connection is created: port, metro or account",
+	"AllowSellerSpeedIncrease":    "Enables keeping connection speed that was increased above configured one by the seller, instead of planning its decrease",
+	"IsRemote":                    "Indicates whether connection spans metros, i.e. metro of buyer's port or device differs from seller's metro",
+	"SellerOrganizationName":      "Name of the seller organization that owns connection's service profile. Empty for connections without service profile",
+	"Profile":                     "Details of connection's service profile. Empty for connections without service profile",
+	"SecondaryUUID":               "Unique identifier of the secondary connection, applicable for HA connections",
+	"SecondaryStatus":             "Secondary connection provisioning status on Equinix Fabric side, applicable for HA connections",
+	"SecondaryProviderStatus":     "Secondary connection provisioning status on service provider's side, applicable for HA connections",
+	"StatusChangedAt":             "Time, in RFC3339 format, of the first refresh that observed current connection status. Taken from local clock, as Fabric API does not report status timestamps",
+	"IsPrimary":                   "Indicates whether connection is a primary connection, as reported in redundancy type",
+	"Fingerprint":                 "Hash of immutable connection parameters, like port, profile, VLAN tags and metro, usable to detect changes requiring connection recreation",
 }
 
+const (
+	ecxL2ConnectionLifecycleStageProvisioning   = "provisioning"
+	ecxL2ConnectionLifecycleStageActive         = "active"
+	ecxL2ConnectionLifecycleStageNeedsAction    = "needs-action"
+	ecxL2ConnectionLifecycleStageDeprovisioning = "deprovisioning"
+	ecxL2ConnectionLifecycleStageDeprovisioned  = "deprovisioned"
+	ecxL2ConnectionLifecycleStageUnknown        = "unknown"
+)
+
+const ecxL2ConnectionRedundancyTypePrimary = "PRIMARY"
+
+const (
+	ecxL2ConnectionNamedTagManual = "Manual"
+	//ecxL2ConnectionZSideServiceKeyInfoName is a name of additional info
+	//that carries z-side service key
+	ecxL2ConnectionZSideServiceKeyInfoName = "serviceKey"
+)
+
+const (
+	ecxL2ConnectionNamedTagPublic    = "Public"
+	ecxL2ConnectionNamedTagMicrosoft = "Microsoft"
+	//ecxL2ConnectionPeeringPrefixesInfoName and ecxL2ConnectionAdvertisedRoutesInfoName
+	//are names of additional infos that carry comma separated lists of
+	//public peering prefixes and advertised routes
+	ecxL2ConnectionPeeringPrefixesInfoName  = "peeringPrefixes"
+	ecxL2ConnectionAdvertisedRoutesInfoName = "advertisedRoutes"
+)
+
+//ecxL2ConnectionActionConfirmConnection is an operation identifier of
+//connection action that has to be completed on service provider's side
+const ecxL2ConnectionActionConfirmConnection = "CONFIRM_CONNECTION"
+
+const ecxPortEncapsulationQinQ = "QinQ"
+
+//ecxL2ConnectionVlanScopePort, ecxL2ConnectionVlanScopeMetro and
+//ecxL2ConnectionVlanScopeAccount define where VLAN tags have to be unique:
+//on the same port, among connections to the same service profile in
+//the same seller metro or among all connections to the same service profile
+const (
+	ecxL2ConnectionVlanScopePort    = "port"
+	ecxL2ConnectionVlanScopeMetro   = "metro"
+	ecxL2ConnectionVlanScopeAccount = "account"
+)
+
+//ecxL2ConnectionDefaultInitialPollDelay is a default delay, in seconds,
+//before first connection status check after create
+const ecxL2ConnectionDefaultInitialPollDelay = 2
+
+const (
+	ecxL2ConnectionCreateRetryAttempts = 3
+	ecxL2ConnectionCreateRetryDelay    = 5 * time.Second
+)
+
+//ecxL2ConnectionAuthorizationKeyFormats describes authorization key formats
+//expected by cloud service providers. Provider is detected by fragments
+//of service profile name or integration identifier
+var ecxL2ConnectionAuthorizationKeyFormats = []struct {
+	provider    string
+	fragments   []string
+	format      *regexp.Regexp
+	description string
+}{
+	{
+		provider:    "AWS",
+		fragments:   []string{"aws", "amazon"},
+		format:      regexp.MustCompile(`^\d{12}$`),
+		description: "12 digit AWS account ID",
+	},
+	{
+		provider:    "Azure",
+		fragments:   []string{"azure"},
+		format:      regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`),
+		description: "ExpressRoute service key GUID",
+	},
+	{
+		provider:    "Google Cloud",
+		fragments:   []string{"google", "gcp"},
+		format:      regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}/[a-z0-9-]+/[12]$`),
+		description: "Partner Interconnect pairing key in <key>/<region>/<1 or 2> format",
+	},
+}
+
+//ecxL2ServiceProfileStateDeleted is a state of service profile deleted by
+//the seller, that no longer accepts connections. ecx-go does not define
+//profile states; this one is reported by the API for removed profiles,
+//as in ecx-go service profile response test fixture
+const ecxL2ServiceProfileStateDeleted = "DELETED"
+
+//ecxL2ConnectionRemovedStatuses lists statuses of connections that are
+//deleted or being deleted
+var ecxL2ConnectionRemovedStatuses = []string{
+	ecx.ConnectionStatusPendingDelete,
+	ecx.ConnectionStatusDeprovisioning,
+	ecx.ConnectionStatusDeprovisioned,
+	ecx.ConnectionStatusDeleted,
+}
+
+//ecxL2ConnectionCreateTargetStatusValues lists statuses that can be used
+//to override default target statuses of create operation
+var ecxL2ConnectionCreateTargetStatusValues = []string{
+	ecx.ConnectionStatusPendingApproval,
+	ecx.ConnectionStatusPendingAutoApproval,
+	ecx.ConnectionStatusProvisioning,
+	ecx.ConnectionStatusPendingBGPPeering,
+	ecx.ConnectionStatusPendingProviderVlan,
+	ecx.ConnectionStatusProvisioned,
+	ecx.ConnectionStatusAvailable,
+	ecx.ConnectionStatusNotAvailable,
+}
+
+//ecxL2ConnectionProviderPendingStatuses lists provider side statuses of
+//connections that are not yet provisioned by service provider
+var ecxL2ConnectionProviderPendingStatuses = []string{
+	ecx.ConnectionStatusNotAvailable,
+	ecx.ConnectionStatusPendingApproval,
+	ecx.ConnectionStatusPendingAutoApproval,
+	ecx.ConnectionStatusProvisioning,
+	ecx.ConnectionStatusPendingProviderVlan,
+	ecx.ConnectionStatusPendingBGPPeering,
+}
+
+//ecxL2ConnectionNonUpdatableKeys lists attributes that cannot be updated
+//by Fabric API, yet are not replacing connection when changed
+var ecxL2ConnectionNonUpdatableKeys = []string{
+	"Notifications",
+	"PurchaseOrderNumber",
+}
+
+//ecxL2ConnectionVlanOccupyingStatuses lists statuses of connections that
+//occupy their port's VLAN tags
+var ecxL2ConnectionVlanOccupyingStatuses = []string{
+	ecx.ConnectionStatusPendingApproval,
+	ecx.ConnectionStatusPendingAutoApproval,
+	ecx.ConnectionStatusProvisioning,
+	ecx.ConnectionStatusPendingBGPPeering,
+	ecx.ConnectionStatusPendingProviderVlan,
+	ecx.ConnectionStatusProvisioned,
+	ecx.ConnectionStatusAvailable,
+	ecx.ConnectionStatusPendingDelete,
+	ecx.ConnectionStatusDeprovisioning,
+}
+
+var ecxL2ConnectionCloudDetailsSchemaNames = map[string]string{
+	"ConnectionID": "connection_id",
+	"VLAN":         "vlan",
+	"ASN":          "asn",
+}
+
+var ecxL2ConnectionCloudDetailsDescriptions = map[string]string{
+	"ConnectionID": "Identifier of the connection on cloud provider's side, i.e. AWS Direct Connect connection identifier",
+	"VLAN":         "VLAN assigned to the connection on cloud provider's side",
+	"ASN":          "BGP Autonomous System Number reported by cloud provider",
+}
+
+//ecxL2ConnectionCloudDetailsKeys maps cloud details attributes to keys
+//of additional information and action required data reported by the platform
+var ecxL2ConnectionCloudDetailsKeys = map[string][]string{
+	"ConnectionID": {"awsConnectionId"},
+	"VLAN":         {"vlan", "providerVlan", "awsVlan"},
+	"ASN":          {"asn", "bgpAsn", "amazonSideAsn", "awsAsn"},
+}
+
+var ecxL2ConnectionProfileSchemaNames = map[string]string{
+	"Name":                     "name",
+	"SpeedBand":                "speed_band",
+	"AuthorizationKeyRequired": "authorization_key_required",
+	"AuthorizationKeyLabel":    "authorization_key_label",
+}
+
+var ecxL2ConnectionProfileDescriptions = map[string]string{
+	"Name":                     "Name of the service profile",
+	"SpeedBand":                "Speed/bandwidth configurations supported by the service profile",
+	"AuthorizationKeyRequired": "Indicates whether service profile requires authorization key to be provided on connection creation",
+	"AuthorizationKeyLabel":    "Name of the authorization key, as defined by the seller, i.e. AWS Account ID",
+}
+
+var ecxL2ConnectionActionSchemaNames = map[string]string{
+	"Type":         "type",
+	"OperationID":  "operation_id",
+	"Message":      "message",
+	"RequiredData": "required_data",
+}
+
+var ecxL2ConnectionActionDescriptions = map[string]string{
+	"Type":         "Action type",
+	"OperationID":  "Identifier of the operation to complete, i.e. CONFIRM_CONNECTION",
+	"Message":      "Action description",
+	"RequiredData": "Data required to complete the action, keyed by data key",
+}
+
+var ecxL2ConnectionProviderStatusHistorySchemaNames = map[string]string{
+	"ProviderStatus": "provider_status",
+	"ObservedAt":     "observed_at",
+}
+
+var ecxL2ConnectionProviderStatusHistoryDescriptions = map[string]string{
+	"ProviderStatus": "Connection provisioning status on service provider's side",
+	"ObservedAt":     "Time, in RFC3339 format, of the first refresh that observed the status. Taken from local clock",
+}
+
+//ecxL2ConnectionProviderStatusHistoryLength limits number of entries
+//kept in provider status history
+const ecxL2ConnectionProviderStatusHistoryLength = 10
+
 var ecxL2ConnectionAdditionalInfoSchemaNames = map[string]string{
 	"Name":  "name",
 	"Value": "value",
@@ -91,8 +368,69 @@ func resourceECXL2Connection() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: createECXL2ConnectionResourceSchema(),
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf(ecxL2ConnectionSchemaNames["SpeedHuman"], func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) bool {
+				return diff.HasChange(ecxL2ConnectionSchemaNames["Speed"]) || diff.HasChange(ecxL2ConnectionSchemaNames["SpeedUnit"])
+			}),
+			customdiff.ComputedIf(ecxL2ConnectionSchemaNames["BandwidthInMbps"], func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) bool {
+				return diff.HasChange(ecxL2ConnectionSchemaNames["Speed"]) || diff.HasChange(ecxL2ConnectionSchemaNames["SpeedUnit"])
+			}),
+			customdiff.ValidateValue(ecxL2ConnectionSchemaNames["Notifications"], func(ctx context.Context, value, meta interface{}) error {
+				conf, ok := meta.(*Config)
+				if !ok {
+					return nil
+				}
+				return validateNotificationDomains(expandSetToStringList(value.(*schema.Set)), conf.AllowedNotificationDomains)
+			}),
+			//Fabric API does not support update of some attributes; changing them
+			//is rejected instead of recreating live connections, unless connection
+			//is replaced anyway. Values changed outside of Terraform are not read
+			//back, so only configuration changes are rejected
+			func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				if diff.Id() == "" || hasForceNewChange(resourceECXL2Connection().Schema, "", diff) {
+					return nil
+				}
+				for _, key := range ecxL2ConnectionNonUpdatableKeys {
+					if diff.HasChange(ecxL2ConnectionSchemaNames[key]) {
+						return fmt.Errorf("%s of an existing connection and its redundant connection cannot be updated by Equinix Fabric API; "+
+							"revert the change, or mark the connection for recreation with terraform taint and apply the change again", ecxL2ConnectionSchemaNames[key])
+					}
+				}
+				return nil
+			},
+			func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				conf, ok := meta.(*Config)
+				if !ok {
+					return nil
+				}
+				return validateECXL2ConnectionProfileDiff(memoizeL2ServiceProfiles(conf.getL2ServiceProfile), diff)
+			},
+			func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				keys := []string{ecxL2ConnectionSchemaNames["ZSideServiceKey"], ecxL2ConnectionSchemaNames["NamedTag"], ecxL2ConnectionSchemaNames["AdditionalInfo"]}
+				for _, key := range keys {
+					if !diff.NewValueKnown(key) {
+						return nil
+					}
+				}
+				return validateECXL2ConnectionZSideServiceKey(diff.Get(keys[0]).(string), diff.Get(keys[1]).(string),
+					expandECXL2ConnectionAdditionalInfo(diff.Get(keys[2]).(*schema.Set)))
+			},
+			func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				keys := []string{ecxL2ConnectionSchemaNames["PeeringPrefixes"], ecxL2ConnectionSchemaNames["AdvertisedRoutes"],
+					ecxL2ConnectionSchemaNames["NamedTag"], ecxL2ConnectionSchemaNames["AdditionalInfo"]}
+				for _, key := range keys {
+					if !diff.NewValueKnown(key) {
+						return nil
+					}
+				}
+				return validateECXL2ConnectionPeeringRoutes(expandSetToStringList(diff.Get(keys[0]).(*schema.Set)),
+					expandSetToStringList(diff.Get(keys[1]).(*schema.Set)), diff.Get(keys[2]).(string),
+					expandECXL2ConnectionAdditionalInfo(diff.Get(keys[3]).(*schema.Set)))
+			},
+		),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 		Description: "Resource allows creation and management of Equinix Fabric	layer 2 connections",
@@ -122,31 +460,70 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 			Description:  ecxL2ConnectionDescriptions["ProfileUUID"],
 		},
 		ecxL2ConnectionSchemaNames["Speed"]: {
-			Type:         schema.TypeInt,
-			Required:     true,
-			ValidateFunc: validation.IntAtLeast(1),
-			Description:  ecxL2ConnectionDescriptions["Speed"],
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.IntAtLeast(1),
+			RequiredWith:     []string{ecxL2ConnectionSchemaNames["SpeedUnit"]},
+			DiffSuppressFunc: suppressECXL2ConnectionSellerSpeedIncreaseDiff,
+			Description:      ecxL2ConnectionDescriptions["Speed"],
 		},
 		ecxL2ConnectionSchemaNames["SpeedUnit"]: {
-			Type:         schema.TypeString,
-			Required:     true,
-			ValidateFunc: validation.StringInSlice([]string{"MB", "GB"}, false),
-			Description:  ecxL2ConnectionDescriptions["SpeedUnit"],
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     validation.StringInSlice([]string{"MB", "GB"}, false),
+			RequiredWith:     []string{ecxL2ConnectionSchemaNames["Speed"]},
+			DiffSuppressFunc: suppressECXL2ConnectionSellerSpeedIncreaseDiff,
+			Description:      ecxL2ConnectionDescriptions["SpeedUnit"],
+		},
+		ecxL2ConnectionSchemaNames["SpeedHuman"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["SpeedHuman"],
+		},
+		ecxL2ConnectionSchemaNames["BandwidthInMbps"]: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["BandwidthInMbps"],
 		},
 		ecxL2ConnectionSchemaNames["Status"]: {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Description: ecxL2ConnectionDescriptions["Status"],
 		},
+		ecxL2ConnectionSchemaNames["StatusChangedAt"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["StatusChangedAt"],
+		},
 		ecxL2ConnectionSchemaNames["ProviderStatus"]: {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Description: ecxL2ConnectionDescriptions["ProviderStatus"],
 		},
+		ecxL2ConnectionSchemaNames["ProviderStatusHistory"]: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["ProviderStatusHistory"],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					ecxL2ConnectionProviderStatusHistorySchemaNames["ProviderStatus"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionProviderStatusHistoryDescriptions["ProviderStatus"],
+					},
+					ecxL2ConnectionProviderStatusHistorySchemaNames["ObservedAt"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionProviderStatusHistoryDescriptions["ObservedAt"],
+					},
+				},
+			},
+		},
 		ecxL2ConnectionSchemaNames["Notifications"]: {
 			Type:     schema.TypeSet,
 			Required: true,
-			ForceNew: true,
 			MinItems: 1,
 			Elem: &schema.Schema{
 				Type:         schema.TypeString,
@@ -157,7 +534,7 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 		ecxL2ConnectionSchemaNames["PurchaseOrderNumber"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
-			ForceNew:     true,
+			Computed:     true,
 			ValidateFunc: validation.StringLenBetween(1, 30),
 			Description:  ecxL2ConnectionDescriptions["PurchaseOrderNumber"],
 		},
@@ -179,18 +556,19 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 			Description:   ecxL2ConnectionDescriptions["DeviceUUID"],
 		},
 		ecxL2ConnectionSchemaNames["DeviceInterfaceID"]: {
-			Type:          schema.TypeInt,
-			Optional:      true,
-			ForceNew:      true,
-			ConflictsWith: []string{ecxL2ConnectionSchemaNames["PortUUID"]},
-			Description:   ecxL2ConnectionDescriptions["DeviceInterfaceID"],
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ForceNew:         true,
+			ConflictsWith:    []string{ecxL2ConnectionSchemaNames["PortUUID"]},
+			DiffSuppressFunc: suppressECXL2ConnectionDeviceInterfaceIDDiff,
+			Description:      ecxL2ConnectionDescriptions["DeviceInterfaceID"],
 		},
 		ecxL2ConnectionSchemaNames["VlanSTag"]: {
 			Type:          schema.TypeInt,
 			Optional:      true,
 			Computed:      true,
 			ForceNew:      true,
-			ValidateFunc:  validation.IntBetween(2, 4092),
+			ValidateFunc:  validation.IntBetween(2, 4094),
 			RequiredWith:  []string{ecxL2ConnectionSchemaNames["PortUUID"]},
 			ConflictsWith: []string{ecxL2ConnectionSchemaNames["DeviceUUID"]},
 			Description:   ecxL2ConnectionDescriptions["VlanSTag"],
@@ -199,7 +577,7 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 			Type:          schema.TypeInt,
 			Optional:      true,
 			ForceNew:      true,
-			ValidateFunc:  validation.IntBetween(2, 4092),
+			ValidateFunc:  validation.Any(validation.IntInSlice([]int{0}), validation.IntBetween(2, 4094)),
 			ConflictsWith: []string{ecxL2ConnectionSchemaNames["DeviceUUID"]},
 			Description:   ecxL2ConnectionDescriptions["VlanCTag"],
 		},
@@ -241,12 +619,41 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 			ValidateFunc: validation.StringIsNotEmpty,
 			Description:  ecxL2ConnectionDescriptions["ZSidePortUUID"],
 		},
+		ecxL2ConnectionSchemaNames["ZSideServiceKey"]: {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			Sensitive:     true,
+			ValidateFunc:  validation.IsUUID,
+			ConflictsWith: []string{ecxL2ConnectionSchemaNames["ZSidePortUUID"]},
+			Description:   ecxL2ConnectionDescriptions["ZSideServiceKey"],
+		},
+		ecxL2ConnectionSchemaNames["PeeringPrefixes"]: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			ForceNew: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsCIDR,
+			},
+			Description: ecxL2ConnectionDescriptions["PeeringPrefixes"],
+		},
+		ecxL2ConnectionSchemaNames["AdvertisedRoutes"]: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			ForceNew: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsCIDR,
+			},
+			Description: ecxL2ConnectionDescriptions["AdvertisedRoutes"],
+		},
 		ecxL2ConnectionSchemaNames["ZSideVlanSTag"]: {
 			Type:         schema.TypeInt,
 			Optional:     true,
 			ForceNew:     true,
 			Computed:     true,
-			ValidateFunc: validation.IntBetween(2, 4092),
+			ValidateFunc: validation.IntBetween(2, 4094),
 			Description:  ecxL2ConnectionDescriptions["ZSideVlanSTag"],
 		},
 		ecxL2ConnectionSchemaNames["ZSideVlanCTag"]: {
@@ -254,9 +661,14 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 			Optional:     true,
 			ForceNew:     true,
 			Computed:     true,
-			ValidateFunc: validation.IntBetween(2, 4092),
+			ValidateFunc: validation.IntBetween(2, 4094),
 			Description:  ecxL2ConnectionDescriptions["ZSideVlanCTag"],
 		},
+		ecxL2ConnectionSchemaNames["ZSideVlan"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["ZSideVlan"],
+		},
 		ecxL2ConnectionSchemaNames["SellerRegion"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
@@ -290,6 +702,26 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: ecxL2ConnectionDescriptions["RedundancyType"],
 		},
+		ecxL2ConnectionSchemaNames["IsPrimary"]: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["IsPrimary"],
+		},
+		ecxL2ConnectionSchemaNames["SecondaryUUID"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["SecondaryUUID"],
+		},
+		ecxL2ConnectionSchemaNames["SecondaryStatus"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["SecondaryStatus"],
+		},
+		ecxL2ConnectionSchemaNames["SecondaryProviderStatus"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["SecondaryProviderStatus"],
+		},
 		ecxL2ConnectionSchemaNames["SecondaryConnection"]: {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -318,21 +750,23 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 						Description:  ecxL2ConnectionDescriptions["ProfileUUID"],
 					},
 					ecxL2ConnectionSchemaNames["Speed"]: {
-						Type:         schema.TypeInt,
-						Optional:     true,
-						Computed:     true,
-						ForceNew:     true,
-						ValidateFunc: validation.IntAtLeast(1),
-						Description:  ecxL2ConnectionDescriptions["Speed"],
+						Type:             schema.TypeInt,
+						Optional:         true,
+						Computed:         true,
+						ForceNew:         true,
+						ValidateFunc:     validation.IntAtLeast(1),
+						DiffSuppressFunc: suppressECXL2ConnectionSellerSpeedIncreaseDiff,
+						Description:      ecxL2ConnectionDescriptions["Speed"],
 					},
 					ecxL2ConnectionSchemaNames["SpeedUnit"]: {
-						Type:         schema.TypeString,
-						Optional:     true,
-						Computed:     true,
-						ForceNew:     true,
-						ValidateFunc: validation.StringInSlice([]string{"MB", "GB"}, false),
-						RequiredWith: []string{ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["Speed"]},
-						Description:  ecxL2ConnectionDescriptions["SpeedUnit"],
+						Type:             schema.TypeString,
+						Optional:         true,
+						Computed:         true,
+						ForceNew:         true,
+						ValidateFunc:     validation.StringInSlice([]string{"MB", "GB"}, false),
+						RequiredWith:     []string{ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["Speed"]},
+						DiffSuppressFunc: suppressECXL2ConnectionSellerSpeedIncreaseDiff,
+						Description:      ecxL2ConnectionDescriptions["SpeedUnit"],
 					},
 					ecxL2ConnectionSchemaNames["Status"]: {
 						Type:        schema.TypeString,
@@ -363,19 +797,20 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 						Description:   ecxL2ConnectionDescriptions["DeviceUUID"],
 					},
 					ecxL2ConnectionSchemaNames["DeviceInterfaceID"]: {
-						Type:          schema.TypeInt,
-						Optional:      true,
-						Computed:      true,
-						ForceNew:      true,
-						ConflictsWith: []string{ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["PortUUID"]},
-						Description:   ecxL2ConnectionDescriptions["DeviceInterfaceID"],
+						Type:             schema.TypeInt,
+						Optional:         true,
+						Computed:         true,
+						ForceNew:         true,
+						ConflictsWith:    []string{ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["PortUUID"]},
+						DiffSuppressFunc: suppressECXL2ConnectionDeviceInterfaceIDDiff,
+						Description:      ecxL2ConnectionDescriptions["DeviceInterfaceID"],
 					},
 					ecxL2ConnectionSchemaNames["VlanSTag"]: {
 						Type:          schema.TypeInt,
 						ForceNew:      true,
 						Optional:      true,
 						Computed:      true,
-						ValidateFunc:  validation.IntBetween(2, 4092),
+						ValidateFunc:  validation.IntBetween(2, 4094),
 						RequiredWith:  []string{ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["PortUUID"]},
 						ConflictsWith: []string{ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["DeviceUUID"]},
 						Description:   ecxL2ConnectionDescriptions["VlanSTag"],
@@ -384,7 +819,7 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 						Type:          schema.TypeInt,
 						ForceNew:      true,
 						Optional:      true,
-						ValidateFunc:  validation.IntBetween(2, 4092),
+						ValidateFunc:  validation.Any(validation.IntInSlice([]int{0}), validation.IntBetween(2, 4094)),
 						ConflictsWith: []string{ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["DeviceUUID"]},
 						Description:   ecxL2ConnectionDescriptions["VlanCTag"],
 					},
@@ -437,6 +872,217 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 						Computed:    true,
 						Description: ecxL2ConnectionDescriptions["RedundancyType"],
 					},
+					ecxL2ConnectionSchemaNames["IsPrimary"]: {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: ecxL2ConnectionDescriptions["IsPrimary"],
+					},
+				},
+			},
+		},
+		ecxL2ConnectionSchemaNames["BGPASN"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["BGPASN"],
+		},
+		ecxL2ConnectionSchemaNames["RequiredConfirmationData"]: {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: ecxL2ConnectionDescriptions["RequiredConfirmationData"],
+		},
+		ecxL2ConnectionSchemaNames["Actions"]: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["Actions"],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					ecxL2ConnectionActionSchemaNames["Type"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionActionDescriptions["Type"],
+					},
+					ecxL2ConnectionActionSchemaNames["OperationID"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionActionDescriptions["OperationID"],
+					},
+					ecxL2ConnectionActionSchemaNames["Message"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionActionDescriptions["Message"],
+					},
+					ecxL2ConnectionActionSchemaNames["RequiredData"]: {
+						Type:     schema.TypeMap,
+						Computed: true,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+						Description: ecxL2ConnectionActionDescriptions["RequiredData"],
+					},
+				},
+			},
+		},
+		ecxL2ConnectionSchemaNames["LifecycleStage"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["LifecycleStage"],
+		},
+		ecxL2ConnectionSchemaNames["Fingerprint"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["Fingerprint"],
+		},
+		ecxL2ConnectionSchemaNames["IsRemote"]: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["IsRemote"],
+		},
+		ecxL2ConnectionSchemaNames["SellerOrganizationName"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["SellerOrganizationName"],
+		},
+		ecxL2ConnectionSchemaNames["Profile"]: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["Profile"],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					ecxL2ConnectionProfileSchemaNames["Name"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionProfileDescriptions["Name"],
+					},
+					ecxL2ConnectionProfileSchemaNames["SpeedBand"]: {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: ecxL2ConnectionProfileDescriptions["SpeedBand"],
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								ecxL2ServiceProfileSpeedBandSchemaNames["Speed"]: {
+									Type:        schema.TypeInt,
+									Computed:    true,
+									Description: ecxL2ServiceProfileSpeedBandDescriptions["Speed"],
+								},
+								ecxL2ServiceProfileSpeedBandSchemaNames["SpeedUnit"]: {
+									Type:        schema.TypeString,
+									Computed:    true,
+									Description: ecxL2ServiceProfileSpeedBandDescriptions["SpeedUnit"],
+								},
+							},
+						},
+					},
+					ecxL2ConnectionProfileSchemaNames["AuthorizationKeyRequired"]: {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: ecxL2ConnectionProfileDescriptions["AuthorizationKeyRequired"],
+					},
+					ecxL2ConnectionProfileSchemaNames["AuthorizationKeyLabel"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionProfileDescriptions["AuthorizationKeyLabel"],
+					},
+				},
+			},
+		},
+		ecxL2ConnectionSchemaNames["AcknowledgeDisruptiveChange"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ecxL2ConnectionDescriptions["AcknowledgeDisruptiveChange"],
+		},
+		ecxL2ConnectionSchemaNames["SkipReadAfterCreate"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ecxL2ConnectionDescriptions["SkipReadAfterCreate"],
+		},
+		ecxL2ConnectionSchemaNames["WaitForProviderStatus"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ecxL2ConnectionDescriptions["WaitForProviderStatus"],
+		},
+		ecxL2ConnectionSchemaNames["PollRequestTimeout"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  ecxL2ConnectionDescriptions["PollRequestTimeout"],
+		},
+		ecxL2ConnectionSchemaNames["RequestTimeout"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  ecxL2ConnectionDescriptions["RequestTimeout"],
+		},
+		ecxL2ConnectionSchemaNames["InitialPollDelay"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      ecxL2ConnectionDefaultInitialPollDelay,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  ecxL2ConnectionDescriptions["InitialPollDelay"],
+		},
+		ecxL2ConnectionSchemaNames["CreateTargetStatuses"]: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			MinItems: 1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(ecxL2ConnectionCreateTargetStatusValues, false),
+			},
+			Description: ecxL2ConnectionDescriptions["CreateTargetStatuses"],
+		},
+		ecxL2ConnectionSchemaNames["DrainBeforeDelete"]: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  ecxL2ConnectionDescriptions["DrainBeforeDelete"],
+		},
+		ecxL2ConnectionSchemaNames["RequireUniqueName"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ecxL2ConnectionDescriptions["RequireUniqueName"],
+		},
+		ecxL2ConnectionSchemaNames["VlanUniquenessScope"]: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  ecxL2ConnectionVlanScopePort,
+			ValidateFunc: validation.StringInSlice([]string{ecxL2ConnectionVlanScopePort,
+				ecxL2ConnectionVlanScopeMetro, ecxL2ConnectionVlanScopeAccount}, false),
+			Description: ecxL2ConnectionDescriptions["VlanUniquenessScope"],
+		},
+		ecxL2ConnectionSchemaNames["AllowSellerSpeedIncrease"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ecxL2ConnectionDescriptions["AllowSellerSpeedIncrease"],
+		},
+		ecxL2ConnectionSchemaNames["CloudDetails"]: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: ecxL2ConnectionDescriptions["CloudDetails"],
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					ecxL2ConnectionCloudDetailsSchemaNames["ConnectionID"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionCloudDetailsDescriptions["ConnectionID"],
+					},
+					ecxL2ConnectionCloudDetailsSchemaNames["VLAN"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionCloudDetailsDescriptions["VLAN"],
+					},
+					ecxL2ConnectionCloudDetailsSchemaNames["ASN"]: {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: ecxL2ConnectionCloudDetailsDescriptions["ASN"],
+					},
 				},
 			},
 		},
@@ -445,106 +1091,296 @@ func createECXL2ConnectionResourceSchema() map[string]*schema.Schema {
 
 func resourceECXL2ConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	client := conf.ecxWithTimeout(getECXL2ConnectionRequestTimeout(d))
+	defer conf.portLocks.lock(getECXL2ConnectionPortUUIDs(d)...)()
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
 	var diags diag.Diagnostics
+	fetchProfile := memoizeL2ServiceProfiles(conf.getL2ServiceProfile)
+	fetchPorts := memoizeUserPorts(conf.getUserPorts)
 	primary, secondary := createECXL2Connections(d)
-	var primaryID *string
-	var err error
-	if secondary != nil {
-		primaryID, _, err = conf.ecx.CreateL2RedundantConnection(*primary, *secondary)
-	} else {
-		primaryID, err = conf.ecx.CreateL2Connection(*primary)
+	if err := fillECXL2ConnectionSpeedFromProfile(fetchProfile, primary); err != nil {
+		return diag.FromErr(err)
 	}
-	if err != nil {
+	if err := validateECXL2ConnectionSpeedBand(fetchProfile, primary, secondary); err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(ecx.StringValue(primaryID))
-	createStateConf := &resource.StateChangeConf{
-		Pending: []string{
-			ecx.ConnectionStatusProvisioning,
-			ecx.ConnectionStatusPendingAutoApproval,
-		},
-		Target: []string{
-			ecx.ConnectionStatusProvisioned,
-			ecx.ConnectionStatusPendingApproval,
-			ecx.ConnectionStatusPendingBGPPeering,
-			ecx.ConnectionStatusPendingProviderVlan,
-		},
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		Delay:      2 * time.Second,
-		MinTimeout: 2 * time.Second,
-		Refresh: func() (interface{}, string, error) {
-			resp, err := conf.ecx.GetL2Connection(d.Id())
-			if err != nil {
-				return nil, "", err
-			}
-			return resp, ecx.StringValue(resp.Status), nil
-		},
+	if err := validateECXL2ConnectionASide(fetchPorts, conf.ne.GetDevice, primary, secondary); err != nil {
+		return diag.FromErr(err)
 	}
-	if _, err := createStateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for connection (%s) to be created: %s", d.Id(), err)
+	if err := validateECXL2ConnectionZSidePort(fetchPorts, primary); err != nil {
+		return diag.FromErr(err)
 	}
-	diags = append(diags, resourceECXL2ConnectionRead(ctx, d, m)...)
-	return diags
-}
-
-func resourceECXL2ConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	conf := m.(*Config)
-	var diags diag.Diagnostics
+	if err := validateECXL2ConnectionTopology(fetchPorts, conf.ne.GetDevice, fetchProfile, primary, secondary); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateECXL2ConnectionCTagEncapsulation(fetchPorts, primary, secondary); err != nil {
+		return diag.FromErr(err)
+	}
+	vlanScope := d.Get(ecxL2ConnectionSchemaNames["VlanUniquenessScope"]).(string)
+	if err := validateECXL2ConnectionVlanTags(client.GetL2OutgoingConnections, vlanScope, primary, secondary); err != nil {
+		return diag.FromErr(err)
+	}
+	if d.Get(ecxL2ConnectionSchemaNames["RequireUniqueName"]).(bool) {
+		if err := validateECXL2ConnectionUniqueName(client.GetL2OutgoingConnections, primary, secondary); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	var profile *ecx.L2ServiceProfile
+	if profileUUID := ecx.StringValue(primary.ProfileUUID); profileUUID != "" {
+		var err error
+		if profile, err = fetchProfile(profileUUID); err != nil {
+			return diag.Errorf("error fetching service profile %q to determine connection approval: %s", profileUUID, err)
+		}
+	}
+	pending, target := getECXL2ConnectionCreateStatuses(profile)
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["CreateTargetStatuses"]); ok {
+		pending, target = overrideECXL2ConnectionCreateTargetStatuses(pending, target, expandSetToStringList(v.(*schema.Set)))
+	}
+	createStats := waitStats{}
+	createStarted := time.Now()
+	primaryID, err := retryECXL2ConnectionCreate(ctx, func() (*string, error) {
+		if secondary != nil {
+			id, _, err := client.CreateL2RedundantConnection(*primary, *secondary)
+			return id, err
+		}
+		return client.CreateL2Connection(*primary)
+	}, ecxL2ConnectionCreateRetryAttempts, ecxL2ConnectionCreateRetryDelay)
+	if err != nil {
+		return restErrorDiagnostics(err, conf.VerboseErrors)
+	}
+	d.SetId(ecx.StringValue(primaryID))
+	lastStage := ecxL2ConnectionLifecycleStageUnknown
+	createStateConf := &resource.StateChangeConf{
+		Pending:    append(pending, statusCheckTimedOutState),
+		Target:     target,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      time.Duration(d.Get(ecxL2ConnectionSchemaNames["InitialPollDelay"]).(int)) * time.Second,
+		MinTimeout: conf.pollInterval(),
+		Refresh: rateLimitAwareRefreshFunc(ctx, requestTimeoutRefreshFunc(ctx, func(pollCtx context.Context) (interface{}, string, error) {
+			resp, err := getECXL2ConnectionPollClient(pollCtx, conf, d).GetL2Connection(d.Id())
+			if err != nil {
+				//newly created connection may not be queryable yet;
+				//not found result is tolerated up to NotFoundChecks times
+				if isRestNotFoundError(err) {
+					log.Printf("[DEBUG] connection %q not found yet", d.Id())
+					return nil, "", nil
+				}
+				return nil, "", err
+			}
+			lastStage = logECXL2ConnectionLifecycleStage(resp)
+			return resp, ecx.StringValue(resp.Status), nil
+		}, getECXL2ConnectionPollRequestTimeout(d)), conf.retryAfter),
+	}
+	result, stats, err := waitForStateContextWithStats(ctx, createStateConf, fmt.Sprintf("connection %q to be created", d.Id()))
+	createStats.duration += stats.duration
+	createStats.polls += stats.polls
+	if err != nil {
+		return diag.Errorf("error waiting for connection (%s) to be created, last lifecycle stage %q: %s", d.Id(), lastStage, err)
+	}
+	created := result.(*ecx.L2Connection)
+	if d.Get(ecxL2ConnectionSchemaNames["WaitForProviderStatus"]).(bool) && ecx.StringValue(created.ProfileUUID) != "" {
+		connIDs := []string{d.Id()}
+		if redundantUUID := ecx.StringValue(created.RedundantUUID); redundantUUID != "" {
+			connIDs = append(connIDs, redundantUUID)
+		}
+		for _, id := range connIDs {
+			refresh := rateLimitAwareRefreshFunc(ctx, requestTimeoutRefreshFunc(ctx, func(pollCtx context.Context) (interface{}, string, error) {
+				return refreshECXL2ConnectionProviderStatus(getECXL2ConnectionPollClient(pollCtx, conf, d).GetL2Connection, id)()
+			}, getECXL2ConnectionPollRequestTimeout(d)), conf.retryAfter)
+			providerStateConf := createECXL2ConnectionProviderStatusWaitConfiguration(refresh, conf.pollInterval(), d.Timeout(schema.TimeoutCreate)-time.Since(createStarted))
+			result, stats, err := waitForStateContextWithStats(ctx, providerStateConf, fmt.Sprintf("connection %q to be provisioned on provider side", id))
+			createStats.duration += stats.duration
+			createStats.polls += stats.polls
+			if err != nil {
+				return diag.Errorf("error waiting for connection (%s) to be provisioned on provider side: %s", id, err)
+			}
+			if id == d.Id() {
+				created = result.(*ecx.L2Connection)
+			}
+		}
+	}
+	log.Printf("[DEBUG] connection %q created in %s, waited %s for status changes, %d status check(s) performed",
+		d.Id(), time.Since(createStarted).Round(time.Millisecond), createStats.duration.Round(time.Millisecond), createStats.polls)
+	conf.notifyLifecycleWebhook(ctx, lifecycleEvent{
+		Action: lifecycleActionCreate,
+		UUID:   d.Id(),
+		Name:   d.Get(ecxL2ConnectionSchemaNames["Name"]).(string),
+		Status: ecx.StringValue(created.Status),
+	})
+	if d.Get(ecxL2ConnectionSchemaNames["SkipReadAfterCreate"]).(bool) {
+		if err := updateECXL2ConnectionResource(created, nil, d); err != nil {
+			return diag.FromErr(err)
+		}
+		return diags
+	}
+	diags = append(diags, resourceECXL2ConnectionRead(ctx, d, m)...)
+	return diags
+}
+
+func createECXL2ConnectionProviderStatusWaitConfiguration(refresh resource.StateRefreshFunc, pollInterval, timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    append([]string{statusCheckTimedOutState}, ecxL2ConnectionProviderPendingStatuses...),
+		Target:     []string{ecx.ConnectionStatusProvisioned},
+		Timeout:    timeout,
+		MinTimeout: pollInterval,
+		Refresh:    refresh,
+	}
+}
+
+//refreshECXL2ConnectionProviderStatus returns refresh function that fetches
+//connection with a given identifier and reports its provider status
+func refreshECXL2ConnectionProviderStatus(fetchFunc getL2Connection, uuid string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := fetchFunc(uuid)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp, ecx.StringValue(resp.ProviderStatus), nil
+	}
+}
+
+func resourceECXL2ConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conf := m.(*Config)
+	client := conf.ecxWithTimeout(getECXL2ConnectionRequestTimeout(d))
+	var diags diag.Diagnostics
 	var err error
 	var primary *ecx.L2Connection
 	var secondary *ecx.L2Connection
 
-	primary, err = conf.ecx.GetL2Connection(d.Id())
+	primary, err = client.GetL2Connection(d.Id())
 	if err != nil {
 		return diag.Errorf("cannot fetch primary connection due to %v", err)
 	}
-	if isStringInSlice(ecx.StringValue(primary.Status), []string{
-		ecx.ConnectionStatusPendingDelete,
-		ecx.ConnectionStatusDeprovisioning,
-		ecx.ConnectionStatusDeprovisioned,
-		ecx.ConnectionStatusDeleted,
-	}) {
+	if isStringInSlice(ecx.StringValue(primary.Status), ecxL2ConnectionRemovedStatuses) {
 		d.SetId("")
 		return nil
 	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["RedundantUUID"], primary.RedundantUUID); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading RedundantUUID: %s", err))
+	}
 	if ecx.StringValue(primary.RedundantUUID) != "" {
-		secondary, err = conf.ecx.GetL2Connection(ecx.StringValue(primary.RedundantUUID))
+		secondary, err = client.GetL2Connection(ecx.StringValue(primary.RedundantUUID))
+		//secondary connection attributes are kept as they were when secondary fetch fails
 		if err != nil {
-			return diag.Errorf("cannot fetch secondary connection due to %v", err)
+			secondary = nil
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Failed to fetch secondary connection with UUID %q", ecx.StringValue(primary.RedundantUUID)),
+				Detail:        fmt.Sprintf("Secondary connection attributes may be stale until next refresh: %s", err),
+				AttributePath: cty.GetAttrPath(ecxL2ConnectionSchemaNames["SecondaryConnection"]),
+			})
 		}
 	}
 	if err := updateECXL2ConnectionResource(primary, secondary, d); err != nil {
 		return diag.FromErr(err)
 	}
+	isRemote := false
+	isRemoteResolved := true
+	if ecx.StringValue(primary.SellerMetroCode) != "" {
+		//is_remote is kept as it was when a-side metro cannot be resolved
+		aSideMetroCode, err := getECXL2ConnectionASideMetroCode(conf.getUserPorts, conf.ne.GetDevice, primary)
+		if err != nil {
+			isRemoteResolved = false
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Failed to resolve a-side metro of connection %q", d.Id()),
+				Detail:        fmt.Sprintf("is_remote attribute may be stale until next refresh: %s", err),
+				AttributePath: cty.GetAttrPath(ecxL2ConnectionSchemaNames["IsRemote"]),
+			})
+		} else {
+			isRemote = isECXL2ConnectionRemote(aSideMetroCode, primary)
+		}
+	}
+	if isRemoteResolved {
+		if err := d.Set(ecxL2ConnectionSchemaNames["IsRemote"], isRemote); err != nil {
+			return diag.FromErr(fmt.Errorf("error reading IsRemote: %s", err))
+		}
+	}
+	fetchProfile := memoizeL2ServiceProfiles(conf.getL2ServiceProfile)
+	if orgName, err := getECXL2ConnectionSellerOrganizationName(fetchProfile, primary); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Failed to resolve seller organization of connection %q", d.Id()),
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath(ecxL2ConnectionSchemaNames["SellerOrganizationName"]),
+		})
+	} else if err := d.Set(ecxL2ConnectionSchemaNames["SellerOrganizationName"], orgName); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading SellerOrganizationName: %s", err))
+	}
+	if profile, err := getECXL2ConnectionProfile(fetchProfile, primary); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Failed to read service profile details of connection %q", d.Id()),
+			Detail:        err.Error(),
+			AttributePath: cty.GetAttrPath(ecxL2ConnectionSchemaNames["Profile"]),
+		})
+	} else if err := d.Set(ecxL2ConnectionSchemaNames["Profile"], flattenECXL2ConnectionProfile(profile)); err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Profile: %s", err))
+	}
+	if isECXL2ConnectionAuthorizationKeyInvalid(primary) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Authorization key of connection %q is invalid or expired", d.Id()),
+			Detail:        "Service provider requires new authorization key for the connection. Please update authorization_key argument with a valid key",
+			AttributePath: cty.GetAttrPath(ecxL2ConnectionSchemaNames["AuthorizationKey"]),
+		})
+	}
 	return diags
 }
 
 func resourceECXL2ConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	client := conf.ecxWithTimeout(getECXL2ConnectionRequestTimeout(d))
+	defer conf.portLocks.lock(getECXL2ConnectionPortUUIDs(d)...)()
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
 	var diags diag.Diagnostics
 	supportedChanges := []string{ecxL2ConnectionSchemaNames["Name"],
 		ecxL2ConnectionSchemaNames["Speed"],
 		ecxL2ConnectionSchemaNames["SpeedUnit"]}
-	primaryChanges := getResourceDataChangedKeys(supportedChanges, d)
-	primaryUpdateReq := conf.ecx.NewL2ConnectionUpdateRequest(d.Id())
-	if err := fillFabricL2ConnectionUpdateRequest(primaryUpdateReq, primaryChanges).Execute(); err != nil {
-		return diag.FromErr(err)
-	}
+	updates := []ecxL2ConnectionUpdate{{
+		uuid: d.Id(),
+		changes: completeECXL2ConnectionBandwidthChanges(getResourceDataChangedKeys(supportedChanges, d),
+			d.Get(ecxL2ConnectionSchemaNames["Speed"]), d.Get(ecxL2ConnectionSchemaNames["SpeedUnit"])),
+	}}
 	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["RedundantUUID"]); ok {
-		secondaryChanges := getResourceDataListElementChanges(supportedChanges, ecxL2ConnectionSchemaNames["SecondaryConnection"], 0, d)
-		secondaryUpdateReq := conf.ecx.NewL2ConnectionUpdateRequest(v.(string))
-		if err := fillFabricL2ConnectionUpdateRequest(secondaryUpdateReq, secondaryChanges).Execute(); err != nil {
+		secondaryPrefix := ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0."
+		updates = append(updates, ecxL2ConnectionUpdate{
+			uuid: v.(string),
+			changes: completeECXL2ConnectionBandwidthChanges(getResourceDataListElementChanges(supportedChanges, ecxL2ConnectionSchemaNames["SecondaryConnection"], 0, d),
+				d.Get(secondaryPrefix+ecxL2ConnectionSchemaNames["Speed"]), d.Get(secondaryPrefix+ecxL2ConnectionSchemaNames["SpeedUnit"])),
+		})
+	}
+	//planned values are not persisted unless all connections are updated
+	d.Partial(true)
+	updated, err := executeECXL2ConnectionUpdates(client.NewL2ConnectionUpdateRequest, updates...)
+	if err != nil {
+		return restErrorDiagnostics(err, conf.VerboseErrors)
+	}
+	for _, uuid := range updated {
+		if err := waitForECXL2ConnectionUpdate(ctx, conf, d, uuid); err != nil {
 			return diag.FromErr(err)
 		}
 	}
+	d.Partial(false)
 	diags = append(diags, resourceECXL2ConnectionRead(ctx, d, m)...)
 	return diags
 }
 
 func resourceECXL2ConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	client := conf.ecxWithTimeout(getECXL2ConnectionRequestTimeout(d))
+	defer conf.portLocks.lock(getECXL2ConnectionPortUUIDs(d)...)()
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
 	var diags diag.Diagnostics
-	if err := conf.ecx.DeleteL2Connection(d.Id()); err != nil {
+	deleteStarted := time.Now()
+	drainPeriod := time.Duration(d.Get(ecxL2ConnectionSchemaNames["DrainBeforeDelete"]).(int)) * time.Second
+	if err := drainECXL2Connection(ctx, d.Id(), drainPeriod); err != nil {
+		return diag.Errorf("error draining connection (%s) before removal: %s", d.Id(), err)
+	}
+	if err := client.DeleteL2Connection(d.Id()); err != nil {
 		restErr, ok := err.(rest.Error)
 		if ok {
 			//IC-LAYER2-4021 = Connection already deleted
@@ -556,7 +1392,7 @@ func resourceECXL2ConnectionDelete(ctx context.Context, d *schema.ResourceData,
 	}
 	//remove secondary connection, don't fail on error as there is no partial state on delete
 	if redID, ok := d.GetOk(ecxL2ConnectionSchemaNames["RedundantUUID"]); ok {
-		if err := conf.ecx.DeleteL2Connection(redID.(string)); err != nil {
+		if err := client.DeleteL2Connection(redID.(string)); err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity:      diag.Warning,
 				Summary:       fmt.Sprintf("Failed to remove secondary connection with UUID %q", redID.(string)),
@@ -565,28 +1401,44 @@ func resourceECXL2ConnectionDelete(ctx context.Context, d *schema.ResourceData,
 			})
 		}
 	}
+	lastStage := ecxL2ConnectionLifecycleStageUnknown
 	deleteStateConf := &resource.StateChangeConf{
+		//connection confirmed on provider side may still be reported as provisioned
+		//until Fabric starts deprovisioning it
 		Pending: []string{
+			ecx.ConnectionStatusProvisioned,
 			ecx.ConnectionStatusDeprovisioning,
+			statusCheckTimedOutState,
 		},
 		Target: []string{
 			ecx.ConnectionStatusPendingDelete,
 			ecx.ConnectionStatusDeprovisioned,
+			ecx.ConnectionStatusDeleted,
 		},
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      2 * time.Second,
-		MinTimeout: 2 * time.Second,
-		Refresh: func() (interface{}, string, error) {
-			resp, err := conf.ecx.GetL2Connection(d.Id())
+		Delay:      conf.pollInterval(),
+		MinTimeout: conf.pollInterval(),
+		Refresh: rateLimitAwareRefreshFunc(ctx, requestTimeoutRefreshFunc(ctx, func(pollCtx context.Context) (interface{}, string, error) {
+			resp, err := getECXL2ConnectionPollClient(pollCtx, conf, d).GetL2Connection(d.Id())
 			if err != nil {
 				return nil, "", err
 			}
+			lastStage = logECXL2ConnectionLifecycleStage(resp)
 			return resp, ecx.StringValue(resp.Status), nil
-		},
-	}
-	if _, err := deleteStateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for connection (%s) to be removed: %s", d.Id(), err)
+		}, getECXL2ConnectionPollRequestTimeout(d)), conf.retryAfter),
 	}
+	result, stats, err := waitForStateContextWithStats(ctx, deleteStateConf, fmt.Sprintf("connection %q to be removed", d.Id()))
+	if err != nil {
+		return diag.Errorf("error waiting for connection (%s) to be removed, last lifecycle stage %q: %s", d.Id(), lastStage, err)
+	}
+	log.Printf("[DEBUG] connection %q removed in %s, waited %s for status changes, %d status check(s) performed",
+		d.Id(), time.Since(deleteStarted).Round(time.Millisecond), stats.duration.Round(time.Millisecond), stats.polls)
+	conf.notifyLifecycleWebhook(ctx, lifecycleEvent{
+		Action: lifecycleActionDelete,
+		UUID:   d.Id(),
+		Name:   d.Get(ecxL2ConnectionSchemaNames["Name"]).(string),
+		Status: ecx.StringValue(result.(*ecx.L2Connection).Status),
+	})
 	return diags
 }
 
@@ -632,6 +1484,24 @@ func createECXL2Connections(d *schema.ResourceData) (*ecx.L2Connection, *ecx.L2C
 	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["AdditionalInfo"]); ok {
 		primary.AdditionalInfo = expandECXL2ConnectionAdditionalInfo(v.(*schema.Set))
 	}
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["ZSideServiceKey"]); ok {
+		primary.AdditionalInfo = append(primary.AdditionalInfo, ecx.L2ConnectionAdditionalInfo{
+			Name:  ecx.String(ecxL2ConnectionZSideServiceKeyInfoName),
+			Value: ecx.String(v.(string)),
+		})
+	}
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["PeeringPrefixes"]); ok {
+		primary.AdditionalInfo = append(primary.AdditionalInfo, ecx.L2ConnectionAdditionalInfo{
+			Name:  ecx.String(ecxL2ConnectionPeeringPrefixesInfoName),
+			Value: ecx.String(strings.Join(expandSetToStringList(v.(*schema.Set)), ",")),
+		})
+	}
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["AdvertisedRoutes"]); ok {
+		primary.AdditionalInfo = append(primary.AdditionalInfo, ecx.L2ConnectionAdditionalInfo{
+			Name:  ecx.String(ecxL2ConnectionAdvertisedRoutesInfoName),
+			Value: ecx.String(strings.Join(expandSetToStringList(v.(*schema.Set)), ",")),
+		})
+	}
 	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["ZSidePortUUID"]); ok {
 		primary.ZSidePortUUID = ecx.String(v.(string))
 	}
@@ -672,17 +1542,41 @@ func updateECXL2ConnectionResource(primary *ecx.L2Connection, secondary *ecx.L2C
 	if err := d.Set(ecxL2ConnectionSchemaNames["SpeedUnit"], primary.SpeedUnit); err != nil {
 		return fmt.Errorf("error reading SpeedUnit: %s", err)
 	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["SpeedHuman"], formatECXL2ConnectionSpeed(primary.Speed, primary.SpeedUnit)); err != nil {
+		return fmt.Errorf("error reading SpeedHuman: %s", err)
+	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["BandwidthInMbps"], getECXL2ConnectionBandwidthInMB(ecx.IntValue(primary.Speed), ecx.StringValue(primary.SpeedUnit))); err != nil {
+		return fmt.Errorf("error reading BandwidthInMbps: %s", err)
+	}
+	statusChangedAt := getECXL2ConnectionStatusChangedAt(d.Get(ecxL2ConnectionSchemaNames["Status"]).(string),
+		d.Get(ecxL2ConnectionSchemaNames["StatusChangedAt"]).(string), ecx.StringValue(primary.Status), time.Now())
+	if err := d.Set(ecxL2ConnectionSchemaNames["StatusChangedAt"], statusChangedAt); err != nil {
+		return fmt.Errorf("error reading StatusChangedAt: %s", err)
+	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["Status"], primary.Status); err != nil {
 		return fmt.Errorf("error reading Status: %s", err)
 	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["ProviderStatus"], primary.ProviderStatus); err != nil {
 		return fmt.Errorf("error reading ProviderStatus: %s", err)
 	}
-	if err := d.Set(ecxL2ConnectionSchemaNames["Notifications"], primary.Notifications); err != nil {
-		return fmt.Errorf("error reading Notifications: %s", err)
+	providerStatusHistory := appendECXL2ConnectionProviderStatusHistory(d.Get(ecxL2ConnectionSchemaNames["ProviderStatusHistory"]).([]interface{}),
+		ecx.StringValue(primary.ProviderStatus), time.Now(), ecxL2ConnectionProviderStatusHistoryLength)
+	if err := d.Set(ecxL2ConnectionSchemaNames["ProviderStatusHistory"], providerStatusHistory); err != nil {
+		return fmt.Errorf("error reading ProviderStatusHistory: %s", err)
+	}
+	//notifications cannot be updated, so ones changed outside of Terraform
+	//are not reconciled; fetched notifications are used only when there are
+	//none in the state, i.e. on import
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["Notifications"]); !ok || v.(*schema.Set).Len() == 0 {
+		if err := d.Set(ecxL2ConnectionSchemaNames["Notifications"], primary.Notifications); err != nil {
+			return fmt.Errorf("error reading Notifications: %s", err)
+		}
 	}
-	if err := d.Set(ecxL2ConnectionSchemaNames["PurchaseOrderNumber"], primary.PurchaseOrderNumber); err != nil {
-		return fmt.Errorf("error reading PurchaseOrderNumber: %s", err)
+	//same applies to purchase order number
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["PurchaseOrderNumber"]); !ok || v.(string) == "" {
+		if err := d.Set(ecxL2ConnectionSchemaNames["PurchaseOrderNumber"], primary.PurchaseOrderNumber); err != nil {
+			return fmt.Errorf("error reading PurchaseOrderNumber: %s", err)
+		}
 	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["PortUUID"], primary.PortUUID); err != nil {
 		return fmt.Errorf("error reading PortUUID: %s", err)
@@ -690,16 +1584,58 @@ func updateECXL2ConnectionResource(primary *ecx.L2Connection, secondary *ecx.L2C
 	if err := d.Set(ecxL2ConnectionSchemaNames["DeviceUUID"], primary.DeviceUUID); err != nil {
 		return fmt.Errorf("error reading DeviceUUID: %s", err)
 	}
+	//device interface is kept as it was when API does not report it
+	if primary.DeviceInterfaceID != nil {
+		if err := d.Set(ecxL2ConnectionSchemaNames["DeviceInterfaceID"], primary.DeviceInterfaceID); err != nil {
+			return fmt.Errorf("error reading DeviceInterfaceID: %s", err)
+		}
+	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["VlanSTag"], primary.VlanSTag); err != nil {
 		return fmt.Errorf("error reading VlanSTag: %s", err)
 	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["VlanCTag"], primary.VlanCTag); err != nil {
 		return fmt.Errorf("error reading VlanCTag: %s", err)
 	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["LifecycleStage"], getECXL2ConnectionLifecycleStage(ecx.StringValue(primary.Status), ecx.StringValue(primary.ProviderStatus))); err != nil {
+		return fmt.Errorf("error reading LifecycleStage: %s", err)
+	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["NamedTag"], primary.NamedTag); err != nil {
 		return fmt.Errorf("error reading NamedTag: %s", err)
 	}
-	if err := d.Set(ecxL2ConnectionSchemaNames["AdditionalInfo"], flattenECXL2ConnectionAdditionalInfo(primary.AdditionalInfo)); err != nil {
+	additionalInfo := primary.AdditionalInfo
+	//z-side service key is kept in its own attribute when it was provided that way
+	if _, ok := d.GetOk(ecxL2ConnectionSchemaNames["ZSideServiceKey"]); ok {
+		var serviceKey *string
+		serviceKey, additionalInfo = extractECXL2ConnectionAdditionalInfo(primary.AdditionalInfo, ecxL2ConnectionZSideServiceKeyInfoName)
+		if serviceKey != nil {
+			if err := d.Set(ecxL2ConnectionSchemaNames["ZSideServiceKey"], serviceKey); err != nil {
+				return fmt.Errorf("error reading ZSideServiceKey: %s", err)
+			}
+		}
+	}
+	//peering prefixes and advertised routes are kept in their own attributes
+	//when they were provided that way
+	if _, ok := d.GetOk(ecxL2ConnectionSchemaNames["PeeringPrefixes"]); ok {
+		var prefixes *string
+		prefixes, additionalInfo = extractECXL2ConnectionAdditionalInfo(additionalInfo, ecxL2ConnectionPeeringPrefixesInfoName)
+		if prefixes != nil {
+			if err := d.Set(ecxL2ConnectionSchemaNames["PeeringPrefixes"], splitECXL2ConnectionInfoList(ecx.StringValue(prefixes))); err != nil {
+				return fmt.Errorf("error reading PeeringPrefixes: %s", err)
+			}
+		}
+	}
+	if _, ok := d.GetOk(ecxL2ConnectionSchemaNames["AdvertisedRoutes"]); ok {
+		var routes *string
+		routes, additionalInfo = extractECXL2ConnectionAdditionalInfo(additionalInfo, ecxL2ConnectionAdvertisedRoutesInfoName)
+		if routes != nil {
+			if err := d.Set(ecxL2ConnectionSchemaNames["AdvertisedRoutes"], splitECXL2ConnectionInfoList(ecx.StringValue(routes))); err != nil {
+				return fmt.Errorf("error reading AdvertisedRoutes: %s", err)
+			}
+		}
+	}
+	additionalInfo = normalizeECXL2ConnectionAdditionalInfoNames(additionalInfo,
+		expandECXL2ConnectionAdditionalInfo(d.Get(ecxL2ConnectionSchemaNames["AdditionalInfo"]).(*schema.Set)))
+	if err := d.Set(ecxL2ConnectionSchemaNames["AdditionalInfo"], flattenECXL2ConnectionAdditionalInfo(additionalInfo)); err != nil {
 		return fmt.Errorf("error reading AdditionalInfo: %s", err)
 	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["ZSidePortUUID"], primary.ZSidePortUUID); err != nil {
@@ -711,6 +1647,9 @@ func updateECXL2ConnectionResource(primary *ecx.L2Connection, secondary *ecx.L2C
 	if err := d.Set(ecxL2ConnectionSchemaNames["ZSideVlanCTag"], primary.ZSideVlanCTag); err != nil {
 		return fmt.Errorf("error reading ZSideVlanCTag: %s", err)
 	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["ZSideVlan"], formatECXL2ConnectionVlan(primary.ZSideVlanSTag, primary.ZSideVlanCTag)); err != nil {
+		return fmt.Errorf("error reading ZSideVlan: %s", err)
+	}
 	if err := d.Set(ecxL2ConnectionSchemaNames["SellerRegion"], primary.SellerRegion); err != nil {
 		return fmt.Errorf("error reading SellerRegion: %s", err)
 	}
@@ -726,6 +1665,24 @@ func updateECXL2ConnectionResource(primary *ecx.L2Connection, secondary *ecx.L2C
 	if err := d.Set(ecxL2ConnectionSchemaNames["RedundancyType"], primary.RedundancyType); err != nil {
 		return fmt.Errorf("error reading RedundancyType: %s", err)
 	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["IsPrimary"], isECXL2ConnectionPrimary(primary)); err != nil {
+		return fmt.Errorf("error reading IsPrimary: %s", err)
+	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["CloudDetails"], flattenECXL2ConnectionCloudDetails(primary)); err != nil {
+		return fmt.Errorf("error reading CloudDetails: %s", err)
+	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["BGPASN"], getECXL2ConnectionDetailsValue(primary, ecxL2ConnectionCloudDetailsKeys["ASN"])); err != nil {
+		return fmt.Errorf("error reading BGPASN: %s", err)
+	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["RequiredConfirmationData"], getECXL2ConnectionRequiredConfirmationData(primary)); err != nil {
+		return fmt.Errorf("error reading RequiredConfirmationData: %s", err)
+	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["Actions"], flattenECXL2ConnectionActions(primary.Actions)); err != nil {
+		return fmt.Errorf("error reading Actions: %s", err)
+	}
+	if err := d.Set(ecxL2ConnectionSchemaNames["Fingerprint"], getECXL2ConnectionFingerprint(primary)); err != nil {
+		return fmt.Errorf("error reading Fingerprint: %s", err)
+	}
 	if secondary != nil {
 		var prevSecondary *ecx.L2Connection
 		if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["SecondaryConnection"]); ok {
@@ -734,6 +1691,22 @@ func updateECXL2ConnectionResource(primary *ecx.L2Connection, secondary *ecx.L2C
 		if err := d.Set(ecxL2ConnectionSchemaNames["SecondaryConnection"], flattenECXL2ConnectionSecondary(prevSecondary, secondary)); err != nil {
 			return fmt.Errorf("error reading SecondaryConnection: %s", err)
 		}
+		if err := d.Set(ecxL2ConnectionSchemaNames["SecondaryUUID"], secondary.UUID); err != nil {
+			return fmt.Errorf("error reading SecondaryUUID: %s", err)
+		}
+		if err := d.Set(ecxL2ConnectionSchemaNames["SecondaryStatus"], secondary.Status); err != nil {
+			return fmt.Errorf("error reading SecondaryStatus: %s", err)
+		}
+		if err := d.Set(ecxL2ConnectionSchemaNames["SecondaryProviderStatus"], secondary.ProviderStatus); err != nil {
+			return fmt.Errorf("error reading SecondaryProviderStatus: %s", err)
+		}
+	} else if ecx.StringValue(primary.RedundantUUID) == "" {
+		//connection no longer has secondary connection
+		for _, key := range []string{"SecondaryUUID", "SecondaryStatus", "SecondaryProviderStatus"} {
+			if err := d.Set(ecxL2ConnectionSchemaNames[key], ""); err != nil {
+				return fmt.Errorf("error reading %s: %s", key, err)
+			}
+		}
 	}
 	return nil
 }
@@ -750,7 +1723,7 @@ func flattenECXL2ConnectionSecondary(previous, conn *ecx.L2Connection) interface
 	transformed[ecxL2ConnectionSchemaNames["PortUUID"]] = conn.PortUUID
 	transformed[ecxL2ConnectionSchemaNames["DeviceUUID"]] = conn.DeviceUUID
 	transformed[ecxL2ConnectionSchemaNames["DeviceInterfaceID"]] = conn.DeviceInterfaceID
-	if previous != nil && ecx.IntValue(previous.DeviceInterfaceID) != 0 {
+	if conn.DeviceInterfaceID == nil && previous != nil && ecx.IntValue(previous.DeviceInterfaceID) != 0 {
 		transformed[ecxL2ConnectionSchemaNames["DeviceInterfaceID"]] = previous.DeviceInterfaceID
 	}
 	transformed[ecxL2ConnectionSchemaNames["VlanSTag"]] = conn.VlanSTag
@@ -763,6 +1736,7 @@ func flattenECXL2ConnectionSecondary(previous, conn *ecx.L2Connection) interface
 	transformed[ecxL2ConnectionSchemaNames["AuthorizationKey"]] = conn.AuthorizationKey
 	transformed[ecxL2ConnectionSchemaNames["RedundantUUID"]] = conn.RedundantUUID
 	transformed[ecxL2ConnectionSchemaNames["RedundancyType"]] = conn.RedundancyType
+	transformed[ecxL2ConnectionSchemaNames["IsPrimary"]] = isECXL2ConnectionPrimary(conn)
 	return []interface{}{transformed}
 }
 
@@ -823,6 +1797,128 @@ func flattenECXL2ConnectionAdditionalInfo(infos []ecx.L2ConnectionAdditionalInfo
 	return transformed
 }
 
+//normalizeECXL2ConnectionAdditionalInfoNames returns given additional infos
+//with names that differ only in casing from previously known names replaced
+//by the previously known ones, as some service providers change their casing
+func normalizeECXL2ConnectionAdditionalInfoNames(infos, previous []ecx.L2ConnectionAdditionalInfo) []ecx.L2ConnectionAdditionalInfo {
+	normalized := make([]ecx.L2ConnectionAdditionalInfo, len(infos))
+	for i, info := range infos {
+		normalized[i] = info
+		for _, prev := range previous {
+			if strings.EqualFold(ecx.StringValue(info.Name), ecx.StringValue(prev.Name)) {
+				normalized[i].Name = prev.Name
+				break
+			}
+		}
+	}
+	return normalized
+}
+
+//extractECXL2ConnectionAdditionalInfo returns value of additional info with
+//a given name along with remaining additional infos
+func extractECXL2ConnectionAdditionalInfo(infos []ecx.L2ConnectionAdditionalInfo, name string) (*string, []ecx.L2ConnectionAdditionalInfo) {
+	var value *string
+	remaining := make([]ecx.L2ConnectionAdditionalInfo, 0, len(infos))
+	for _, info := range infos {
+		if ecx.StringValue(info.Name) == name {
+			value = info.Value
+			continue
+		}
+		remaining = append(remaining, info)
+	}
+	return value, remaining
+}
+
+//validateECXL2ConnectionZSideServiceKey verifies that z-side service key
+//is used only with Manual named tag and is not duplicated in additional info
+func validateECXL2ConnectionZSideServiceKey(serviceKey, namedTag string, infos []ecx.L2ConnectionAdditionalInfo) error {
+	if serviceKey == "" {
+		return nil
+	}
+	if namedTag != ecxL2ConnectionNamedTagManual {
+		return fmt.Errorf("%s can be used only when %s is %q", ecxL2ConnectionSchemaNames["ZSideServiceKey"],
+			ecxL2ConnectionSchemaNames["NamedTag"], ecxL2ConnectionNamedTagManual)
+	}
+	if v, _ := extractECXL2ConnectionAdditionalInfo(infos, ecxL2ConnectionZSideServiceKeyInfoName); v != nil {
+		return fmt.Errorf("%s conflicts with %q entry of %s", ecxL2ConnectionSchemaNames["ZSideServiceKey"],
+			ecxL2ConnectionZSideServiceKeyInfoName, ecxL2ConnectionSchemaNames["AdditionalInfo"])
+	}
+	return nil
+}
+
+//validateECXL2ConnectionPeeringRoutes verifies that peering prefixes and
+//advertised routes are used only with Public or Microsoft named tag
+//and are not duplicated in additional info
+func validateECXL2ConnectionPeeringRoutes(prefixes, routes []string, namedTag string, infos []ecx.L2ConnectionAdditionalInfo) error {
+	lists := []struct {
+		attr     string
+		infoName string
+		values   []string
+	}{
+		{ecxL2ConnectionSchemaNames["PeeringPrefixes"], ecxL2ConnectionPeeringPrefixesInfoName, prefixes},
+		{ecxL2ConnectionSchemaNames["AdvertisedRoutes"], ecxL2ConnectionAdvertisedRoutesInfoName, routes},
+	}
+	for _, list := range lists {
+		if len(list.values) == 0 {
+			continue
+		}
+		if namedTag != ecxL2ConnectionNamedTagPublic && namedTag != ecxL2ConnectionNamedTagMicrosoft {
+			return fmt.Errorf("%s can be used only when %s is %q or %q", list.attr, ecxL2ConnectionSchemaNames["NamedTag"],
+				ecxL2ConnectionNamedTagPublic, ecxL2ConnectionNamedTagMicrosoft)
+		}
+		if v, _ := extractECXL2ConnectionAdditionalInfo(infos, list.infoName); v != nil {
+			return fmt.Errorf("%s conflicts with %q entry of %s", list.attr, list.infoName, ecxL2ConnectionSchemaNames["AdditionalInfo"])
+		}
+	}
+	return nil
+}
+
+//splitECXL2ConnectionInfoList splits comma separated additional info value
+func splitECXL2ConnectionInfoList(value string) []string {
+	result := make([]string, 0)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func flattenECXL2ConnectionCloudDetails(conn *ecx.L2Connection) interface{} {
+	transformed := make(map[string]interface{})
+	for attr, keys := range ecxL2ConnectionCloudDetailsKeys {
+		if v := getECXL2ConnectionDetailsValue(conn, keys); v != "" {
+			transformed[ecxL2ConnectionCloudDetailsSchemaNames[attr]] = v
+		}
+	}
+	if len(transformed) == 0 {
+		return []interface{}{}
+	}
+	return []interface{}{transformed}
+}
+
+//getECXL2ConnectionDetailsValue returns first non empty value for given keys
+//found in connection's additional information or actions required data
+func getECXL2ConnectionDetailsValue(conn *ecx.L2Connection, keys []string) string {
+	values := make(map[string]string)
+	for _, info := range conn.AdditionalInfo {
+		values[ecx.StringValue(info.Name)] = ecx.StringValue(info.Value)
+	}
+	for _, action := range conn.Actions {
+		for _, actionData := range action.RequiredData {
+			if ecx.StringValue(actionData.Value) != "" {
+				values[ecx.StringValue(actionData.Key)] = ecx.StringValue(actionData.Value)
+			}
+		}
+	}
+	for _, key := range keys {
+		if v, ok := values[key]; ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func expandECXL2ConnectionAdditionalInfo(infos *schema.Set) []ecx.L2ConnectionAdditionalInfo {
 	transformed := make([]ecx.L2ConnectionAdditionalInfo, 0, infos.Len())
 	for _, info := range infos.List() {
@@ -835,16 +1931,1026 @@ func expandECXL2ConnectionAdditionalInfo(infos *schema.Set) []ecx.L2ConnectionAd
 	return transformed
 }
 
-func fillFabricL2ConnectionUpdateRequest(updateReq ecx.L2ConnectionUpdateRequest, changes map[string]interface{}) ecx.L2ConnectionUpdateRequest {
-	for change, changeValue := range changes {
-		switch change {
-		case ecxL2ConnectionSchemaNames["Name"]:
-			updateReq.WithName(changeValue.(string))
-		case ecxL2ConnectionSchemaNames["Speed"]:
-			updateReq.WithSpeed(changeValue.(int))
-		case ecxL2ConnectionSchemaNames["SpeedUnit"]:
-			updateReq.WithSpeedUnit(changeValue.(string))
+func flattenECXL2ConnectionActions(actions []ecx.L2ConnectionAction) interface{} {
+	transformed := make([]interface{}, 0, len(actions))
+	for _, action := range actions {
+		requiredData := make(map[string]interface{})
+		for _, actionData := range action.RequiredData {
+			if key := ecx.StringValue(actionData.Key); key != "" {
+				requiredData[key] = ecx.StringValue(actionData.Value)
+			}
+		}
+		transformed = append(transformed, map[string]interface{}{
+			ecxL2ConnectionActionSchemaNames["Type"]:         ecx.StringValue(action.Type),
+			ecxL2ConnectionActionSchemaNames["OperationID"]:  ecx.StringValue(action.OperationID),
+			ecxL2ConnectionActionSchemaNames["Message"]:      ecx.StringValue(action.Message),
+			ecxL2ConnectionActionSchemaNames["RequiredData"]: requiredData,
+		})
+	}
+	return transformed
+}
+
+//getECXL2ConnectionRequiredConfirmationData returns data, keyed by data key,
+//required by connection's confirmation action
+func getECXL2ConnectionRequiredConfirmationData(conn *ecx.L2Connection) map[string]string {
+	transformed := make(map[string]string)
+	for _, action := range conn.Actions {
+		if ecx.StringValue(action.OperationID) != ecxL2ConnectionActionConfirmConnection {
+			continue
+		}
+		for _, actionData := range action.RequiredData {
+			if key := ecx.StringValue(actionData.Key); key != "" {
+				transformed[key] = ecx.StringValue(actionData.Value)
+			}
 		}
 	}
+	return transformed
+}
+
+//isECXL2ConnectionAuthorizationKeyInvalid checks if connection has pending
+//action that requires providing new authorization key
+func isECXL2ConnectionAuthorizationKeyInvalid(conn *ecx.L2Connection) bool {
+	for _, action := range conn.Actions {
+		for _, actionData := range action.RequiredData {
+			if ecx.StringValue(actionData.Key) == "authorizationKey" && ecx.BoolValue(actionData.IsEditable) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//getECXL2ConnectionLifecycleStage maps connection status and provider status
+//into a coarse lifecycle stage
+func getECXL2ConnectionLifecycleStage(status, providerStatus string) string {
+	switch status {
+	case ecx.ConnectionStatusProvisioning,
+		ecx.ConnectionStatusPendingAutoApproval:
+		return ecxL2ConnectionLifecycleStageProvisioning
+	case ecx.ConnectionStatusPendingApproval,
+		ecx.ConnectionStatusPendingBGPPeering,
+		ecx.ConnectionStatusPendingProviderVlan,
+		ecx.ConnectionStatusRejected:
+		return ecxL2ConnectionLifecycleStageNeedsAction
+	case ecx.ConnectionStatusProvisioned:
+		switch providerStatus {
+		case ecx.ConnectionStatusPendingApproval,
+			ecx.ConnectionStatusPendingBGPPeering,
+			ecx.ConnectionStatusRejected:
+			return ecxL2ConnectionLifecycleStageNeedsAction
+		case ecx.ConnectionStatusProvisioning:
+			return ecxL2ConnectionLifecycleStageProvisioning
+		case ecx.ConnectionStatusPendingDelete,
+			ecx.ConnectionStatusDeprovisioning:
+			return ecxL2ConnectionLifecycleStageDeprovisioning
+		}
+		return ecxL2ConnectionLifecycleStageActive
+	case ecx.ConnectionStatusPendingDelete,
+		ecx.ConnectionStatusDeprovisioning:
+		return ecxL2ConnectionLifecycleStageDeprovisioning
+	case ecx.ConnectionStatusDeprovisioned,
+		ecx.ConnectionStatusDeleted:
+		return ecxL2ConnectionLifecycleStageDeprovisioned
+	}
+	return ecxL2ConnectionLifecycleStageUnknown
+}
+
+func logECXL2ConnectionLifecycleStage(conn *ecx.L2Connection) string {
+	stage := getECXL2ConnectionLifecycleStage(ecx.StringValue(conn.Status), ecx.StringValue(conn.ProviderStatus))
+	log.Printf("[DEBUG] connection %q is in %s stage (status: %q, provider status: %q)",
+		ecx.StringValue(conn.UUID), stage, ecx.StringValue(conn.Status), ecx.StringValue(conn.ProviderStatus))
+	return stage
+}
+
+//getECXL2ConnectionPortUUIDs returns UUIDs of ports used by primary and
+//secondary connection, so operations on connections sharing a port
+//can be serialized
+func getECXL2ConnectionPortUUIDs(d *schema.ResourceData) []string {
+	return []string{d.Get(ecxL2ConnectionSchemaNames["PortUUID"]).(string),
+		d.Get(ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["PortUUID"]).(string)}
+}
+
+//getECXL2ConnectionRequestTimeout returns API request timeout configured
+//for a connection or zero when provider's request timeout applies
+func getECXL2ConnectionRequestTimeout(d *schema.ResourceData) time.Duration {
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["RequestTimeout"]); ok {
+		return time.Duration(v.(int)) * time.Second
+	}
+	return 0
+}
+
+func getECXL2ConnectionPollRequestTimeout(d *schema.ResourceData) time.Duration {
+	if v, ok := d.GetOk(ecxL2ConnectionSchemaNames["PollRequestTimeout"]); ok {
+		return time.Duration(v.(int)) * time.Second
+	}
+	return 0
+}
+
+//getECXL2ConnectionPollClient returns client for connection status checks
+//which requests are sent with a given context, i.e. one cancelled by
+//requestTimeoutRefreshFunc once poll request timeout elapses
+func getECXL2ConnectionPollClient(ctx context.Context, conf *Config, d *schema.ResourceData) ecx.Client {
+	return conf.ecxWithContext(ctx, getECXL2ConnectionRequestTimeout(d))
+}
+
+//suppressECXL2ConnectionDeviceInterfaceIDDiff suppresses device interface
+//identifier differences for existing connections configured to use first
+//available interface, so interface reported by the API is not replaced
+func suppressECXL2ConnectionDeviceInterfaceIDDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	return new == "" || new == "0"
+}
+
+type createL2Connection func() (*string, error)
+
+//suppressECXL2ConnectionSellerSpeedIncreaseDiff suppresses speed and speed
+//unit diffs of connections which bandwidth was increased by the seller above
+//configured one, when allow_seller_speed_increase is enabled
+func suppressECXL2ConnectionSellerSpeedIncreaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" || !d.Get(ecxL2ConnectionSchemaNames["AllowSellerSpeedIncrease"]).(bool) {
+		return false
+	}
+	prefix := k[:strings.LastIndex(k, ".")+1]
+	oldSpeed, newSpeed := d.GetChange(prefix + ecxL2ConnectionSchemaNames["Speed"])
+	oldSpeedUnit, newSpeedUnit := d.GetChange(prefix + ecxL2ConnectionSchemaNames["SpeedUnit"])
+	return isECXL2ConnectionSpeedIncreasedBySeller(oldSpeed.(int), oldSpeedUnit.(string), newSpeed.(int), newSpeedUnit.(string))
+}
+
+//isECXL2ConnectionSpeedIncreasedBySeller checks if current bandwidth
+//of a connection is greater than configured one
+func isECXL2ConnectionSpeedIncreasedBySeller(speed int, speedUnit string, configSpeed int, configSpeedUnit string) bool {
+	configBandwidth := getECXL2ConnectionBandwidthInMB(configSpeed, configSpeedUnit)
+	return configBandwidth > 0 && getECXL2ConnectionBandwidthInMB(speed, speedUnit) > configBandwidth
+}
+
+func getECXL2ConnectionBandwidthInMB(speed int, speedUnit string) int {
+	if strings.EqualFold(speedUnit, "GB") {
+		return speed * 1000
+	}
+	return speed
+}
+
+//retryECXL2ConnectionCreate runs given create function and retries it, with
+//exponential backoff, when it fails with transient service profile error
+func retryECXL2ConnectionCreate(ctx context.Context, createFunc createL2Connection, attempts int, delay time.Duration) (*string, error) {
+	for i := 1; ; i++ {
+		id, err := createFunc()
+		if err == nil || i >= attempts || !isECXL2ConnectionCreateRetryableError(err) {
+			return id, err
+		}
+		log.Printf("[WARN] connection create attempt %d of %d failed with transient error, retrying in %s: %s", i, attempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+//drainECXL2Connection waits given period before connection removal
+//is requested, so traffic can be shifted away from the connection
+func drainECXL2Connection(ctx context.Context, uuid string, period time.Duration) error {
+	if period <= 0 {
+		return nil
+	}
+	log.Printf("[INFO] draining connection %q for %s before removal", uuid, period)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(period):
+	}
+	return nil
+}
+
+//isECXL2ConnectionCreateRetryableError checks if connection create failed
+//because Fabric was temporarily unable to handle it, i.e. service profile was
+//temporarily unavailable, which is reported with HTTP 503 Service Unavailable
+func isECXL2ConnectionCreateRetryableError(err error) bool {
+	restErr, ok := err.(rest.Error)
+	if !ok {
+		return false
+	}
+	return restErr.HTTPCode == http.StatusServiceUnavailable
+}
+
+type getUserPorts func() ([]ecx.Port, error)
+
+//validateECXL2ConnectionZSidePort verifies that z-side port of a connection
+//without service profile belongs to the account that creates the connection
+func validateECXL2ConnectionZSidePort(fetchFunc getUserPorts, conn *ecx.L2Connection) error {
+	zSidePortUUID := ecx.StringValue(conn.ZSidePortUUID)
+	if zSidePortUUID == "" || ecx.StringValue(conn.ProfileUUID) != "" {
+		return nil
+	}
+	ports, err := fetchFunc()
+	if err != nil {
+		return fmt.Errorf("error fetching user ports to validate z-side port: %s", err)
+	}
+	if isECXPortInList(zSidePortUUID, ports) {
+		return nil
+	}
+	return fmt.Errorf("z-side port %q is not available for this account; connections without %s"+
+		" can be established only to ports owned by the same account", zSidePortUUID, ecxL2ConnectionSchemaNames["ProfileUUID"])
+}
+
+type getL2ServiceProfile func(uuid string) (*ecx.L2ServiceProfile, error)
+
+//memoizeL2ServiceProfiles wraps given fetch function so each service profile
+//is fetched at most once. Lookups made within single operation share profiles
+//regardless of provider's lookup cache settings
+func memoizeL2ServiceProfiles(fetchFunc getL2ServiceProfile) getL2ServiceProfile {
+	profiles := make(map[string]*ecx.L2ServiceProfile)
+	return func(uuid string) (*ecx.L2ServiceProfile, error) {
+		if profile, ok := profiles[uuid]; ok {
+			return profile, nil
+		}
+		profile, err := fetchFunc(uuid)
+		if err != nil {
+			return nil, err
+		}
+		profiles[uuid] = profile
+		return profile, nil
+	}
+}
+
+//memoizeUserPorts wraps given fetch function so user ports are fetched at
+//most once. Lookups made within single operation share ports regardless
+//of provider's lookup cache settings
+func memoizeUserPorts(fetchFunc getUserPorts) getUserPorts {
+	var ports []ecx.Port
+	fetched := false
+	return func() ([]ecx.Port, error) {
+		if fetched {
+			return ports, nil
+		}
+		var err error
+		if ports, err = fetchFunc(); err != nil {
+			return nil, err
+		}
+		fetched = true
+		return ports, nil
+	}
+}
+
+//fillECXL2ConnectionSpeedFromProfile sets speed and speed unit of a connection
+//without them, using the only speed band supported by connection's service profile
+func fillECXL2ConnectionSpeedFromProfile(fetchFunc getL2ServiceProfile, conn *ecx.L2Connection) error {
+	if conn.Speed != nil && conn.SpeedUnit != nil {
+		return nil
+	}
+	profileUUID := ecx.StringValue(conn.ProfileUUID)
+	if profileUUID == "" {
+		return fmt.Errorf("%s and %s are required for connections without %s", ecxL2ConnectionSchemaNames["Speed"],
+			ecxL2ConnectionSchemaNames["SpeedUnit"], ecxL2ConnectionSchemaNames["ProfileUUID"])
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		return fmt.Errorf("error fetching service profile %q to determine connection speed: %s", profileUUID, err)
+	}
+	if len(profile.SpeedBands) != 1 {
+		return fmt.Errorf("service profile %q supports %d speed bands, %s and %s have to be specified", profileUUID,
+			len(profile.SpeedBands), ecxL2ConnectionSchemaNames["Speed"], ecxL2ConnectionSchemaNames["SpeedUnit"])
+	}
+	conn.Speed = profile.SpeedBands[0].Speed
+	conn.SpeedUnit = profile.SpeedBands[0].SpeedUnit
+	return nil
+}
+
+//validateECXL2ConnectionSpeedBand verifies that speed and speed unit of
+//connections are among speed bands offered by their service profiles.
+//Connections without service profile and profiles that allow custom
+//speed are not validated
+func validateECXL2ConnectionSpeedBand(fetchFunc getL2ServiceProfile, conns ...*ecx.L2Connection) error {
+	for _, conn := range conns {
+		if conn == nil || conn.Speed == nil || conn.SpeedUnit == nil {
+			continue
+		}
+		profileUUID := ecx.StringValue(conn.ProfileUUID)
+		if profileUUID == "" {
+			continue
+		}
+		profile, err := fetchFunc(profileUUID)
+		if err != nil {
+			return fmt.Errorf("error fetching service profile %q to validate connection speed: %s", profileUUID, err)
+		}
+		if ecx.BoolValue(profile.AllowCustomSpeed) || len(profile.SpeedBands) == 0 {
+			continue
+		}
+		bands := make([]string, len(profile.SpeedBands))
+		supported := false
+		for i, band := range profile.SpeedBands {
+			bands[i] = formatECXL2ConnectionSpeed(band.Speed, band.SpeedUnit)
+			if ecx.IntValue(band.Speed) == ecx.IntValue(conn.Speed) && strings.EqualFold(ecx.StringValue(band.SpeedUnit), ecx.StringValue(conn.SpeedUnit)) {
+				supported = true
+			}
+		}
+		if !supported {
+			return fmt.Errorf("speed %s is not supported by service profile %q (%s), supported speeds are: %s",
+				formatECXL2ConnectionSpeed(conn.Speed, conn.SpeedUnit), profileUUID, ecx.StringValue(profile.Name), strings.Join(bands, ", "))
+		}
+	}
+	return nil
+}
+
+//validateECXL2ConnectionProfileDiff runs service profile based validations
+//of planned connection changes
+func validateECXL2ConnectionProfileDiff(fetchFunc getL2ServiceProfile, diff *schema.ResourceDiff) error {
+	if err := validateECXL2ConnectionSellerRegionDiff(fetchFunc, diff); err != nil {
+		return err
+	}
+	for _, key := range []string{ecxL2ConnectionSchemaNames["ProfileUUID"],
+		ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["ProfileUUID"]} {
+		if !diff.NewValueKnown(key) || !diff.HasChange(key) {
+			continue
+		}
+		if err := validateECXL2ConnectionProfileState(fetchFunc, diff.Get(key).(string)); err != nil {
+			return err
+		}
+	}
+	if err := validateECXL2ConnectionAuthorizationKeyDiff(fetchFunc, diff); err != nil {
+		return err
+	}
+	profileKey := ecxL2ConnectionSchemaNames["ProfileUUID"]
+	if diff.Id() == "" && diff.NewValueKnown(profileKey) {
+		hasSecondary := len(diff.Get(ecxL2ConnectionSchemaNames["SecondaryConnection"]).([]interface{})) > 0
+		if err := validateECXL2ConnectionRedundancyRequirement(fetchFunc, diff.Get(profileKey).(string), hasSecondary); err != nil {
+			return err
+		}
+	}
+	if diff.Id() != "" && hasECXL2ConnectionSpeedChange(diff) {
+		return validateECXL2ConnectionDisruptiveSpeedChange(fetchFunc, diff.Get(profileKey).(string),
+			diff.Get(ecxL2ConnectionSchemaNames["AcknowledgeDisruptiveChange"]).(bool))
+	}
+	return nil
+}
+
+//validateECXL2ConnectionSellerRegionDiff verifies that seller region of primary
+//and secondary connection, when planned, is available in connection's seller metro
+func validateECXL2ConnectionSellerRegionDiff(fetchFunc getL2ServiceProfile, diff *schema.ResourceDiff) error {
+	profileUUID := ""
+	for _, prefix := range []string{"", ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0."} {
+		profileKey := prefix + ecxL2ConnectionSchemaNames["ProfileUUID"]
+		metroKey := prefix + ecxL2ConnectionSchemaNames["SellerMetroCode"]
+		regionKey := prefix + ecxL2ConnectionSchemaNames["SellerRegion"]
+		if !diff.NewValueKnown(profileKey) || !diff.NewValueKnown(metroKey) || !diff.NewValueKnown(regionKey) {
+			continue
+		}
+		//secondary connection uses primary connection's profile unless specified
+		if v := diff.Get(profileKey).(string); v != "" {
+			profileUUID = v
+		}
+		if !diff.HasChange(profileKey) && !diff.HasChange(metroKey) && !diff.HasChange(regionKey) {
+			continue
+		}
+		if err := validateECXL2ConnectionSellerRegion(fetchFunc, profileUUID, diff.Get(metroKey).(string), diff.Get(regionKey).(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//validateECXL2ConnectionAuthorizationKeyDiff verifies that authorization key
+//of primary and secondary connection, when planned, matches format expected
+//by cloud service provider of connection's service profile
+func validateECXL2ConnectionAuthorizationKeyDiff(fetchFunc getL2ServiceProfile, diff *schema.ResourceDiff) error {
+	profileUUID := ""
+	for _, prefix := range []string{"", ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0."} {
+		profileKey := prefix + ecxL2ConnectionSchemaNames["ProfileUUID"]
+		authKey := prefix + ecxL2ConnectionSchemaNames["AuthorizationKey"]
+		if !diff.NewValueKnown(profileKey) || !diff.NewValueKnown(authKey) {
+			continue
+		}
+		//secondary connection uses primary connection's profile unless specified
+		if v := diff.Get(profileKey).(string); v != "" {
+			profileUUID = v
+		}
+		if !diff.HasChange(profileKey) && !diff.HasChange(authKey) {
+			continue
+		}
+		if err := validateECXL2ConnectionAuthorizationKey(fetchFunc, profileUUID, diff.Get(authKey).(string)); err != nil {
+			return fmt.Errorf("invalid %s%s: %s", prefix, ecxL2ConnectionSchemaNames["AuthorizationKey"], err)
+		}
+	}
+	return nil
+}
+
+//validateECXL2ConnectionAuthorizationKey verifies that given authorization key
+//matches format expected by cloud service provider of a service profile.
+//Validation is skipped when profile is not available or its provider is not known
+func validateECXL2ConnectionAuthorizationKey(fetchFunc getL2ServiceProfile, profileUUID, key string) error {
+	if profileUUID == "" || key == "" {
+		return nil
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		log.Printf("[WARN] skipping authorization key validation, error fetching service profile %q: %s", profileUUID, err)
+		return nil
+	}
+	profileID := strings.ToLower(ecx.StringValue(profile.Name) + " " + ecx.StringValue(profile.IntegrationID))
+	for _, keyFormat := range ecxL2ConnectionAuthorizationKeyFormats {
+		for _, fragment := range keyFormat.fragments {
+			if !strings.Contains(profileID, fragment) {
+				continue
+			}
+			if !keyFormat.format.MatchString(key) {
+				return fmt.Errorf("service profile %q of %s expects %s", ecx.StringValue(profile.Name), keyFormat.provider, keyFormat.description)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+//validateECXL2ConnectionRedundancyRequirement verifies that connection to
+//the service profile that requires redundancy has secondary connection
+func validateECXL2ConnectionRedundancyRequirement(fetchFunc getL2ServiceProfile, profileUUID string, hasSecondary bool) error {
+	if profileUUID == "" || hasSecondary {
+		return nil
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		log.Printf("[WARN] skipping service profile redundancy validation, error fetching service profile %q: %s", profileUUID, err)
+		return nil
+	}
+	if ecx.BoolValue(profile.RequiredRedundancy) {
+		return fmt.Errorf("service profile %q (%s) requires redundant connections; please configure %s block",
+			profileUUID, ecx.StringValue(profile.Name), ecxL2ConnectionSchemaNames["SecondaryConnection"])
+	}
+	return nil
+}
+
+//validateECXL2ConnectionProfileState verifies that service profile
+//was not deleted by the seller
+func validateECXL2ConnectionProfileState(fetchFunc getL2ServiceProfile, profileUUID string) error {
+	if profileUUID == "" {
+		return nil
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		log.Printf("[WARN] skipping service profile state validation, error fetching service profile %q: %s", profileUUID, err)
+		return nil
+	}
+	if state := ecx.StringValue(profile.State); strings.EqualFold(state, ecxL2ServiceProfileStateDeleted) {
+		return fmt.Errorf("service profile %q (%s) is in %q state and no longer accepts connections; please select an active"+
+			" service profile, i.e. using equinix_ecx_l2_sellerprofile data source", profileUUID, ecx.StringValue(profile.Name), state)
+	}
+	return nil
+}
+
+//validateECXL2ConnectionSellerRegion verifies that given seller region is
+//available in given seller metro of a service profile. Validation is skipped
+//when profile or its metro details are not available
+func validateECXL2ConnectionSellerRegion(fetchFunc getL2ServiceProfile, profileUUID, metroCode, region string) error {
+	if profileUUID == "" || metroCode == "" || region == "" {
+		return nil
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		log.Printf("[WARN] skipping seller region validation, error fetching service profile %q: %s", profileUUID, err)
+		return nil
+	}
+	for _, metro := range profile.Metros {
+		if !strings.EqualFold(ecx.StringValue(metro.Code), metroCode) || len(metro.Regions) == 0 {
+			continue
+		}
+		if _, ok := metro.Regions[region]; ok {
+			return nil
+		}
+		regions := make([]string, 0, len(metro.Regions))
+		for k := range metro.Regions {
+			regions = append(regions, k)
+		}
+		sort.Strings(regions)
+		return fmt.Errorf("%s %q is not available in %s %q of service profile %q, available regions: %s",
+			ecxL2ConnectionSchemaNames["SellerRegion"], region, ecxL2ConnectionSchemaNames["SellerMetroCode"], metroCode,
+			profileUUID, strings.Join(regions, ", "))
+	}
+	return nil
+}
+
+//getECXL2ConnectionSellerOrganizationName returns name of the organization
+//that owns connection's service profile. Connections without
+//service profile, i.e. port to port connections, have no seller organization
+func getECXL2ConnectionSellerOrganizationName(fetchFunc getL2ServiceProfile, conn *ecx.L2Connection) (string, error) {
+	profileUUID := ecx.StringValue(conn.ProfileUUID)
+	if profileUUID == "" {
+		return "", nil
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		return "", fmt.Errorf("error fetching service profile %q: %s", profileUUID, err)
+	}
+	if name := ecx.StringValue(profile.OrganizationName); name != "" {
+		return name, nil
+	}
+	return ecx.StringValue(profile.GlobalOrganization), nil
+}
+
+//getECXL2ConnectionProfile fetches service profile of the connection.
+//Nil profile is returned for connections without service profile
+func getECXL2ConnectionProfile(fetchFunc getL2ServiceProfile, conn *ecx.L2Connection) (*ecx.L2ServiceProfile, error) {
+	profileUUID := ecx.StringValue(conn.ProfileUUID)
+	if profileUUID == "" {
+		return nil, nil
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service profile %q: %s", profileUUID, err)
+	}
+	return profile, nil
+}
+
+func flattenECXL2ConnectionProfile(profile *ecx.L2ServiceProfile) interface{} {
+	if profile == nil {
+		return []interface{}{}
+	}
+	authKeyLabel := ecx.StringValue(profile.AuthKeyLabel)
+	return []interface{}{
+		map[string]interface{}{
+			ecxL2ConnectionProfileSchemaNames["Name"]:                     ecx.StringValue(profile.Name),
+			ecxL2ConnectionProfileSchemaNames["SpeedBand"]:                flattenECXL2ServiceProfileSpeedBands(profile.SpeedBands),
+			ecxL2ConnectionProfileSchemaNames["AuthorizationKeyRequired"]: authKeyLabel != "",
+			ecxL2ConnectionProfileSchemaNames["AuthorizationKeyLabel"]:    authKeyLabel,
+		},
+	}
+}
+
+//hasECXL2ConnectionSpeedChange checks if speed or speed unit of primary
+//or secondary connection is planned to change
+func hasECXL2ConnectionSpeedChange(diff *schema.ResourceDiff) bool {
+	secondaryPrefix := ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0."
+	return diff.HasChange(ecxL2ConnectionSchemaNames["Speed"]) ||
+		diff.HasChange(ecxL2ConnectionSchemaNames["SpeedUnit"]) ||
+		diff.HasChange(secondaryPrefix+ecxL2ConnectionSchemaNames["Speed"]) ||
+		diff.HasChange(secondaryPrefix+ecxL2ConnectionSchemaNames["SpeedUnit"])
+}
+
+//validateECXL2ConnectionDisruptiveSpeedChange blocks unacknowledged speed
+//changes of connections to service profiles with speed driven by service
+//provider's API, as such changes are performed by the provider and may
+//briefly interrupt the service. Validation is skipped when profile is not available
+func validateECXL2ConnectionDisruptiveSpeedChange(fetchFunc getL2ServiceProfile, profileUUID string, acknowledged bool) error {
+	if profileUUID == "" || acknowledged {
+		return nil
+	}
+	profile, err := fetchFunc(profileUUID)
+	if err != nil {
+		log.Printf("[WARN] skipping disruptive speed change validation, error fetching service profile %q: %s", profileUUID, err)
+		return nil
+	}
+	if !ecx.BoolValue(profile.SpeedFromAPI) {
+		return nil
+	}
+	return fmt.Errorf("speed change of connection to service profile %q may briefly interrupt the service; set %s to true to proceed",
+		ecx.StringValue(profile.Name), ecxL2ConnectionSchemaNames["AcknowledgeDisruptiveChange"])
+}
+
+type getL2OutgoingConnections func(statuses []string) ([]ecx.L2Connection, error)
+
+//validateECXL2ConnectionVlanTags verifies that port based connections do not
+//conflict with existing connections on the same port. Connections on QinQ port
+//may share S-Tag as long as their C-Tags differ
+func validateECXL2ConnectionVlanTags(fetchFunc getL2OutgoingConnections, scope string, conns ...*ecx.L2Connection) error {
+	var toValidate []*ecx.L2Connection
+	for _, conn := range conns {
+		if conn != nil && ecx.StringValue(conn.PortUUID) != "" && ecx.IntValue(conn.VlanSTag) != 0 {
+			toValidate = append(toValidate, conn)
+		}
+	}
+	if len(toValidate) == 0 {
+		return nil
+	}
+	existing, err := fetchFunc(ecxL2ConnectionVlanOccupyingStatuses)
+	if err != nil {
+		return fmt.Errorf("error fetching existing connections to validate VLAN tags: %s", err)
+	}
+	siblings := make([]*ecx.L2Connection, len(existing))
+	for i := range existing {
+		siblings[i] = &existing[i]
+	}
+	for _, conn := range toValidate {
+		for _, sibling := range siblings {
+			if isECXL2ConnectionVlanConflict(conn, sibling) {
+				return fmt.Errorf("VLAN S-Tag %d and C-Tag %d are already used on port %q by connection %q",
+					ecx.IntValue(conn.VlanSTag), ecx.IntValue(conn.VlanCTag), ecx.StringValue(conn.PortUUID), ecx.StringValue(sibling.Name))
+			}
+			if isECXL2ConnectionVlanScopeConflict(scope, conn, sibling) {
+				return fmt.Errorf("VLAN S-Tag %d and C-Tag %d are already used within %s scope by connection %q on port %q",
+					ecx.IntValue(conn.VlanSTag), ecx.IntValue(conn.VlanCTag), scope, ecx.StringValue(sibling.Name), ecx.StringValue(sibling.PortUUID))
+			}
+		}
+		siblings = append(siblings, conn)
+	}
+	return nil
+}
+
+//validateECXL2ConnectionUniqueName verifies that names of given connections
+//are not used by any existing connection, nor by each other
+func validateECXL2ConnectionUniqueName(fetchFunc getL2OutgoingConnections, conns ...*ecx.L2Connection) error {
+	existing, err := fetchFunc(ecxL2ConnectionVlanOccupyingStatuses)
+	if err != nil {
+		return fmt.Errorf("error fetching existing connections to validate connection name: %s", err)
+	}
+	names := make(map[string]string, len(existing))
+	for _, conn := range existing {
+		names[ecx.StringValue(conn.Name)] = ecx.StringValue(conn.UUID)
+	}
+	for _, conn := range conns {
+		if conn == nil {
+			continue
+		}
+		name := ecx.StringValue(conn.Name)
+		if uuid, ok := names[name]; ok {
+			if uuid == "" {
+				return fmt.Errorf("connection name %q is used by both primary and secondary connection", name)
+			}
+			return fmt.Errorf("connection name %q is already used by connection %q", name, uuid)
+		}
+		names[name] = ""
+	}
+	return nil
+}
+
+func isECXL2ConnectionVlanConflict(conn, other *ecx.L2Connection) bool {
+	return ecx.StringValue(conn.PortUUID) == ecx.StringValue(other.PortUUID) &&
+		ecx.IntValue(conn.VlanSTag) == ecx.IntValue(other.VlanSTag) &&
+		ecx.IntValue(conn.VlanCTag) == ecx.IntValue(other.VlanCTag)
+}
+
+//isECXL2ConnectionVlanScopeConflict checks if connections to the same
+//service profile use same VLAN tags within metro or account scope.
+//Port scope conflicts are covered by isECXL2ConnectionVlanConflict
+func isECXL2ConnectionVlanScopeConflict(scope string, conn, other *ecx.L2Connection) bool {
+	if scope != ecxL2ConnectionVlanScopeMetro && scope != ecxL2ConnectionVlanScopeAccount {
+		return false
+	}
+	if ecx.StringValue(conn.ProfileUUID) == "" || ecx.StringValue(conn.ProfileUUID) != ecx.StringValue(other.ProfileUUID) {
+		return false
+	}
+	if scope == ecxL2ConnectionVlanScopeMetro && ecx.StringValue(conn.SellerMetroCode) != ecx.StringValue(other.SellerMetroCode) {
+		return false
+	}
+	return ecx.IntValue(conn.VlanSTag) == ecx.IntValue(other.VlanSTag) &&
+		ecx.IntValue(conn.VlanCTag) == ecx.IntValue(other.VlanCTag)
+}
+
+type getNetworkDevice func(uuid string) (*ne.Device, error)
+
+//validateECXL2ConnectionASide verifies that ports and devices from which
+//given connections originate belong to the authenticated account
+func validateECXL2ConnectionASide(fetchPorts getUserPorts, fetchDevice getNetworkDevice, conns ...*ecx.L2Connection) error {
+	var ports []ecx.Port
+	validatedDevices := make(map[string]struct{})
+	for _, conn := range conns {
+		if conn == nil {
+			continue
+		}
+		if portUUID := ecx.StringValue(conn.PortUUID); portUUID != "" {
+			if ports == nil {
+				var err error
+				if ports, err = fetchPorts(); err != nil {
+					return fmt.Errorf("error fetching user ports to validate connection origin: %s", err)
+				}
+			}
+			if !isECXPortInList(portUUID, ports) {
+				return fmt.Errorf("port %q is not available for this account; connections can originate only"+
+					" from ports owned by the authenticated account", portUUID)
+			}
+		}
+		deviceUUID := ecx.StringValue(conn.DeviceUUID)
+		if _, ok := validatedDevices[deviceUUID]; deviceUUID == "" || ok {
+			continue
+		}
+		if _, err := fetchDevice(deviceUUID); err != nil {
+			if restErr, ok := err.(rest.Error); ok && (restErr.HTTPCode == http.StatusNotFound || restErr.HTTPCode == http.StatusForbidden) {
+				return fmt.Errorf("device %q is not available for this account; connections can originate only"+
+					" from devices owned by the authenticated account", deviceUUID)
+			}
+			return fmt.Errorf("error fetching device %q to validate connection origin: %s", deviceUUID, err)
+		}
+		validatedDevices[deviceUUID] = struct{}{}
+	}
+	return nil
+}
+
+//validateECXL2ConnectionCTagEncapsulation verifies that C-Tags are set only
+//for connections originating from QinQ ports. Connections without C-Tag are untagged
+func validateECXL2ConnectionCTagEncapsulation(fetchFunc getUserPorts, conns ...*ecx.L2Connection) error {
+	var ports []ecx.Port
+	for _, conn := range conns {
+		if conn == nil || ecx.StringValue(conn.PortUUID) == "" || ecx.IntValue(conn.VlanCTag) == 0 {
+			continue
+		}
+		if ports == nil {
+			var err error
+			if ports, err = fetchFunc(); err != nil {
+				return fmt.Errorf("error fetching user ports to validate C-Tag: %s", err)
+			}
+		}
+		for _, port := range ports {
+			if ecx.StringValue(port.UUID) != ecx.StringValue(conn.PortUUID) {
+				continue
+			}
+			if encapsulation := ecx.StringValue(port.Encapsulation); encapsulation != "" && !strings.EqualFold(encapsulation, ecxPortEncapsulationQinQ) {
+				return fmt.Errorf("port %q uses %s encapsulation which does not support C-Tag; remove %s or set it to 0",
+					ecx.StringValue(conn.PortUUID), encapsulation, ecxL2ConnectionSchemaNames["VlanCTag"])
+			}
+		}
+	}
+	return nil
+}
+
+func isECXPortInList(uuid string, ports []ecx.Port) bool {
+	for _, port := range ports {
+		if ecx.StringValue(port.UUID) == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+//getECXL2ConnectionASideMetroCode resolves metro code of a buyer's port
+//or Network Edge device from which a given connection originates
+func getECXL2ConnectionASideMetroCode(fetchPorts getUserPorts, fetchDevice getNetworkDevice, conn *ecx.L2Connection) (string, error) {
+	if deviceUUID := ecx.StringValue(conn.DeviceUUID); deviceUUID != "" {
+		device, err := fetchDevice(deviceUUID)
+		if err != nil {
+			return "", fmt.Errorf("error fetching device %q: %s", deviceUUID, err)
+		}
+		return ne.StringValue(device.MetroCode), nil
+	}
+	portUUID := ecx.StringValue(conn.PortUUID)
+	if portUUID == "" {
+		return "", nil
+	}
+	ports, err := fetchPorts()
+	if err != nil {
+		return "", fmt.Errorf("error fetching user ports: %s", err)
+	}
+	for _, port := range ports {
+		if ecx.StringValue(port.UUID) == portUUID {
+			return ecx.StringValue(port.MetroCode), nil
+		}
+	}
+	return "", nil
+}
+
+//validateECXL2ConnectionTopology verifies that service profile is present
+//in seller metro and, for remote connections, that it can be reached from
+//the metro where the connection originates. Secondary connection without
+//own profile or seller metro uses ones of the primary connection
+func validateECXL2ConnectionTopology(fetchPorts getUserPorts, fetchDevice getNetworkDevice, fetchProfile getL2ServiceProfile, primary, secondary *ecx.L2Connection) error {
+	for _, conn := range []*ecx.L2Connection{primary, secondary} {
+		if conn == nil {
+			continue
+		}
+		profileUUID := ecx.StringValue(conn.ProfileUUID)
+		if profileUUID == "" {
+			profileUUID = ecx.StringValue(primary.ProfileUUID)
+		}
+		sellerMetroCode := ecx.StringValue(conn.SellerMetroCode)
+		if sellerMetroCode == "" {
+			sellerMetroCode = ecx.StringValue(primary.SellerMetroCode)
+		}
+		if profileUUID == "" || sellerMetroCode == "" {
+			continue
+		}
+		aSideMetroCode, err := getECXL2ConnectionASideMetroCode(fetchPorts, fetchDevice, conn)
+		if err != nil {
+			return fmt.Errorf("error resolving connection a-side metro to validate connection topology: %s", err)
+		}
+		profile, err := fetchProfile(profileUUID)
+		if err != nil {
+			return fmt.Errorf("error fetching service profile %q to validate connection topology: %s", profileUUID, err)
+		}
+		if len(profile.Metros) > 0 && !isECXL2ServiceProfileInMetro(profile, sellerMetroCode) {
+			return fmt.Errorf("service profile %q is not available in %s %q", profileUUID,
+				ecxL2ConnectionSchemaNames["SellerMetroCode"], sellerMetroCode)
+		}
+		if aSideMetroCode == "" || strings.EqualFold(aSideMetroCode, sellerMetroCode) {
+			continue
+		}
+		if profile.Features.CloudReach != nil && !*profile.Features.CloudReach {
+			return fmt.Errorf("service profile %q does not support remote connections; connection originating in metro %q"+
+				" cannot reach %s %q", profileUUID, aSideMetroCode, ecxL2ConnectionSchemaNames["SellerMetroCode"], sellerMetroCode)
+		}
+	}
+	return nil
+}
+
+func isECXL2ServiceProfileInMetro(profile *ecx.L2ServiceProfile, metroCode string) bool {
+	for _, metro := range profile.Metros {
+		if strings.EqualFold(ecx.StringValue(metro.Code), metroCode) {
+			return true
+		}
+	}
+	return false
+}
+
+//isECXL2ConnectionRemote checks if a-side metro of a connection differs
+//from its seller metro. Connections with unknown metros are not remote
+func isECXL2ConnectionRemote(aSideMetroCode string, conn *ecx.L2Connection) bool {
+	zSideMetroCode := ecx.StringValue(conn.SellerMetroCode)
+	if aSideMetroCode == "" || zSideMetroCode == "" {
+		return false
+	}
+	return !strings.EqualFold(aSideMetroCode, zSideMetroCode)
+}
+
+//getECXL2ConnectionFingerprint returns hash of connection parameters
+//that cannot be changed without connection recreation
+func getECXL2ConnectionFingerprint(conn *ecx.L2Connection) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s-", ecx.StringValue(conn.ProfileUUID)))
+	buf.WriteString(fmt.Sprintf("%s-", ecx.StringValue(conn.PortUUID)))
+	buf.WriteString(fmt.Sprintf("%s-", ecx.StringValue(conn.DeviceUUID)))
+	buf.WriteString(fmt.Sprintf("%d-", ecx.IntValue(conn.VlanSTag)))
+	buf.WriteString(fmt.Sprintf("%d-", ecx.IntValue(conn.VlanCTag)))
+	buf.WriteString(fmt.Sprintf("%s-", ecx.StringValue(conn.ZSidePortUUID)))
+	buf.WriteString(fmt.Sprintf("%d-", ecx.IntValue(conn.ZSideVlanSTag)))
+	buf.WriteString(fmt.Sprintf("%d-", ecx.IntValue(conn.ZSideVlanCTag)))
+	buf.WriteString(fmt.Sprintf("%s-", ecx.StringValue(conn.SellerMetroCode)))
+	buf.WriteString(fmt.Sprintf("%s-", ecx.StringValue(conn.SellerRegion)))
+	return fmt.Sprintf("%x", sha256.Sum256(buf.Bytes()))
+}
+
+//getECXL2ConnectionCreateStatuses returns pending and target statuses
+//of connection creation. Connections to profiles with API integration are
+//approved automatically, so awaiting approval is not a final status for them
+func getECXL2ConnectionCreateStatuses(profile *ecx.L2ServiceProfile) ([]string, []string) {
+	pending := []string{
+		ecx.ConnectionStatusProvisioning,
+		ecx.ConnectionStatusPendingAutoApproval,
+	}
+	target := []string{
+		ecx.ConnectionStatusProvisioned,
+		ecx.ConnectionStatusPendingBGPPeering,
+		ecx.ConnectionStatusPendingProviderVlan,
+	}
+	if profile != nil && ecx.BoolValue(profile.APIAvailable) {
+		return append(pending, ecx.ConnectionStatusPendingApproval), target
+	}
+	return pending, append(target, ecx.ConnectionStatusPendingApproval)
+}
+
+//overrideECXL2ConnectionCreateTargetStatuses replaces default target statuses
+//of create operation with given ones. Default target statuses that are not
+//overridden become pending, so create keeps waiting when they are observed
+func overrideECXL2ConnectionCreateTargetStatuses(pending, target, override []string) ([]string, []string) {
+	var newPending []string
+	for _, statuses := range [][]string{pending, target} {
+		for _, status := range statuses {
+			if !isStringInSlice(status, override) {
+				newPending = append(newPending, status)
+			}
+		}
+	}
+	return newPending, override
+}
+
+//appendECXL2ConnectionProviderStatusHistory adds given provider status to
+//the history when it differs from most recently observed one. Only given
+//number of most recent entries is kept
+func appendECXL2ConnectionProviderStatusHistory(history []interface{}, status string, now time.Time, maxLen int) []interface{} {
+	if status == "" {
+		return history
+	}
+	if len(history) > 0 {
+		last := history[len(history)-1].(map[string]interface{})
+		if last[ecxL2ConnectionProviderStatusHistorySchemaNames["ProviderStatus"]] == status {
+			return history
+		}
+	}
+	history = append(history, map[string]interface{}{
+		ecxL2ConnectionProviderStatusHistorySchemaNames["ProviderStatus"]: status,
+		ecxL2ConnectionProviderStatusHistorySchemaNames["ObservedAt"]:     now.UTC().Format(time.RFC3339),
+	})
+	if len(history) > maxLen {
+		history = history[len(history)-maxLen:]
+	}
+	return history
+}
+
+//getECXL2ConnectionStatusChangedAt returns time when status change was observed.
+//Fabric API does not report status timestamps, therefore previously observed
+//time is kept until status changes
+func getECXL2ConnectionStatusChangedAt(prevStatus, prevChangedAt, status string, now time.Time) string {
+	if prevStatus == status && prevChangedAt != "" {
+		return prevChangedAt
+	}
+	return now.UTC().Format(time.RFC3339)
+}
+
+func isECXL2ConnectionPrimary(conn *ecx.L2Connection) bool {
+	return strings.EqualFold(ecx.StringValue(conn.RedundancyType), ecxL2ConnectionRedundancyTypePrimary)
+}
+
+func formatECXL2ConnectionSpeed(speed *int, speedUnit *string) string {
+	if speed == nil || ecx.StringValue(speedUnit) == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d %s", ecx.IntValue(speed), ecx.StringValue(speedUnit))
+}
+
+//formatECXL2ConnectionVlan returns VLAN tagging as S-Tag, or S-Tag.C-Tag
+//when connection has both tags, i.e. on QinQ ports
+func formatECXL2ConnectionVlan(sTag *int, cTag *int) string {
+	if ecx.IntValue(sTag) == 0 {
+		return ""
+	}
+	if ecx.IntValue(cTag) == 0 {
+		return strconv.Itoa(ecx.IntValue(sTag))
+	}
+	return fmt.Sprintf("%d.%d", ecx.IntValue(sTag), ecx.IntValue(cTag))
+}
+
+func fillFabricL2ConnectionUpdateRequest(updateReq ecx.L2ConnectionUpdateRequest, changes map[string]interface{}) ecx.L2ConnectionUpdateRequest {
+	speed, speedChanged := changes[ecxL2ConnectionSchemaNames["Speed"]]
+	speedUnit, speedUnitChanged := changes[ecxL2ConnectionSchemaNames["SpeedUnit"]]
+	switch {
+	case speedChanged && speedUnitChanged:
+		updateReq.WithBandwidth(speed.(int), speedUnit.(string))
+	case speedChanged:
+		updateReq.WithSpeed(speed.(int))
+	case speedUnitChanged:
+		updateReq.WithSpeedUnit(speedUnit.(string))
+	}
+	if name, ok := changes[ecxL2ConnectionSchemaNames["Name"]]; ok {
+		updateReq.WithName(name.(string))
+	}
 	return updateReq
 }
+
+//completeECXL2ConnectionBandwidthChanges adds current speed and speed unit
+//to connection changes when either of them changed, as Fabric updates
+//connection bandwidth only when both are given
+func completeECXL2ConnectionBandwidthChanges(changes map[string]interface{}, speed, speedUnit interface{}) map[string]interface{} {
+	_, speedChanged := changes[ecxL2ConnectionSchemaNames["Speed"]]
+	_, speedUnitChanged := changes[ecxL2ConnectionSchemaNames["SpeedUnit"]]
+	if speedChanged || speedUnitChanged {
+		changes[ecxL2ConnectionSchemaNames["Speed"]] = speed
+		changes[ecxL2ConnectionSchemaNames["SpeedUnit"]] = speedUnit
+	}
+	return changes
+}
+
+//ecxL2ConnectionUpdate describes changes of a single connection
+type ecxL2ConnectionUpdate struct {
+	uuid    string
+	changes map[string]interface{}
+}
+
+type newL2ConnectionUpdateRequest func(uuid string) ecx.L2ConnectionUpdateRequest
+
+//executeECXL2ConnectionUpdates sends single update request, with all
+//changes, per each changed connection. Identifiers of updated
+//connections are returned
+func executeECXL2ConnectionUpdates(newReqFunc newL2ConnectionUpdateRequest, updates ...ecxL2ConnectionUpdate) ([]string, error) {
+	var updated []string
+	for _, update := range updates {
+		if len(update.changes) == 0 {
+			continue
+		}
+		if err := fillFabricL2ConnectionUpdateRequest(newReqFunc(update.uuid), update.changes).Execute(); err != nil {
+			return updated, fmt.Errorf("error updating connection %q: %w", update.uuid, err)
+		}
+		updated = append(updated, update.uuid)
+	}
+	return updated, nil
+}
+
+//waitForECXL2ConnectionUpdate waits until updated connection
+//is no longer being provisioned
+func waitForECXL2ConnectionUpdate(ctx context.Context, conf *Config, d *schema.ResourceData, uuid string) error {
+	updateStateConf := &resource.StateChangeConf{
+		Pending: []string{
+			ecx.ConnectionStatusProvisioning,
+			ecx.ConnectionStatusPendingAutoApproval,
+			statusCheckTimedOutState,
+		},
+		Target: []string{
+			ecx.ConnectionStatusProvisioned,
+			ecx.ConnectionStatusPendingApproval,
+			ecx.ConnectionStatusPendingBGPPeering,
+			ecx.ConnectionStatusPendingProviderVlan,
+			ecx.ConnectionStatusAvailable,
+		},
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      conf.pollInterval(),
+		MinTimeout: conf.pollInterval(),
+		Refresh: rateLimitAwareRefreshFunc(ctx, requestTimeoutRefreshFunc(ctx, func(pollCtx context.Context) (interface{}, string, error) {
+			resp, err := getECXL2ConnectionPollClient(pollCtx, conf, d).GetL2Connection(uuid)
+			if err != nil {
+				return nil, "", err
+			}
+			return resp, ecx.StringValue(resp.Status), nil
+		}, getECXL2ConnectionPollRequestTimeout(d)), conf.retryAfter),
+	}
+	if _, err := updateStateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for connection (%s) to be updated: %s", uuid, err)
+	}
+	return nil
+}
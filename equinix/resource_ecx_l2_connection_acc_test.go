@@ -160,6 +160,8 @@ func TestAccFabricL2Connection_Port_HA_Azure(t *testing.T) {
 					testAccFabricL2ConnectionSecondaryAttributes(&secondary, context),
 					resource.TestCheckResourceAttr(resourceName, "status", ecx.ConnectionStatusProvisioned),
 					resource.TestCheckResourceAttrSet(resourceName, "provider_status"),
+					resource.TestCheckResourceAttrSet(resourceName, "zside_port_uuid"),
+					resource.TestCheckResourceAttrSet(resourceName, "secondary_connection.0.zside_port_uuid"),
 					testAccFabricL2ConnectionRedundancyAttributes(&primary, &secondary),
 				),
 			},
@@ -241,6 +243,15 @@ func TestAccFabricL2Connection_Device_HA_GCP(t *testing.T) {
 					testAccFabricL2ConnectionSecondaryAttributes(&secondary, context),
 				),
 			},
+			{
+				ResourceName:      connResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					ecxL2ConnectionSchemaNames["DeviceInterfaceID"],
+					ecxL2ConnectionSchemaNames["SecondaryConnection"] + ".0." + ecxL2ConnectionSchemaNames["DeviceInterfaceID"],
+				},
+			},
 		},
 	})
 }
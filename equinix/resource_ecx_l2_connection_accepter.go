@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/equinix/ecx-go/v2"
+	"github.com/equinix/rest-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,25 +18,89 @@ import (
 )
 
 var ecxL2ConnectionAccepterSchemaNames = map[string]string{
-	"ConnectionId":    "connection_id",
-	"AccessKey":       "access_key",
-	"SecretKey":       "secret_key",
-	"Profile":         "aws_profile",
-	"AWSConnectionID": "aws_connection_id",
+	"ConnectionId":              "connection_id",
+	"AccessKey":                 "access_key",
+	"SecretKey":                 "secret_key",
+	"Profile":                   "aws_profile",
+	"AWSConnectionID":           "aws_connection_id",
+	"ProviderVlan":              "provider_vlan",
+	"ServiceKey":                "service_key",
+	"DeleteConnectionOnDestroy": "delete_connection_on_destroy",
+	"GCP":                       "gcp_partner_interconnect",
+	"GCPPairingKey":             "gcp_pairing_key",
 }
 
 var ecxL2ConnectionAccepterDescriptions = map[string]string{
-	"ConnectionId":    "Identifier of layer 2 connection that will be accepted",
-	"AccessKey":       "Access Key used to accept connection on provider side",
-	"SecretKey":       "Secret Key used to accept connection on provider side",
-	"Profile":         "AWS Profile Name for retrieving credentials from shared credentials file",
-	"AWSConnectionID": "Identifier of a hosted Direct Connect connection on AWS side, applicable for accepter resource with connections to AWS only",
+	"ConnectionId":              "Identifier of layer 2 connection that will be accepted",
+	"AccessKey":                 "Access Key used to accept connection on provider side",
+	"SecretKey":                 "Secret Key used to accept connection on provider side",
+	"Profile":                   "AWS Profile Name for retrieving credentials from shared credentials file",
+	"AWSConnectionID":           "Identifier of a hosted Direct Connect connection on AWS side, applicable for accepter resource with connections to AWS only",
+	"ProviderVlan":              "VLAN assigned to the connection by service provider, available once connection is confirmed",
+	"ServiceKey":                "Azure ExpressRoute service key of the connection. When set, connection is accepted without AWS credentials and resource waits for Azure to provision it",
+	"DeleteConnectionOnDestroy": "Deletes Equinix Fabric connection that is still pending approval on provider side when resource is destroyed. Connection is not rejected on provider side; deleted connection is reported as removed by equinix_ecx_l2_connection resource that manages it. Provisioned connections are left intact",
+	"GCP":                       "Enables Google Cloud Partner Interconnect mode, in which connection is accepted without AWS credentials and resource waits for Google Cloud to provision it",
+	"GCPPairingKey":             "Google Cloud Partner Interconnect pairing key of the connection, applicable for accepter resource in Google Cloud mode only",
+}
+
+//ecxL2ConnectionAccepterDeletableStatuses lists provider side statuses
+//of connections that are deleted when accepter resource is destroyed
+var ecxL2ConnectionAccepterDeletableStatuses = []string{
+	ecx.ConnectionStatusPendingApproval,
+	ecx.ConnectionStatusPendingProviderVlan,
+}
+
+//ecxL2ConnectionAccepterProviderVlanKeys lists names of connection additional
+//info and confirmation data that hold provider assigned VLAN
+var ecxL2ConnectionAccepterProviderVlanKeys = []string{
+	"providerVlan",
+	"vlan",
+	"vlanId",
+}
+
+//ecxL2ConnectionAccepterGCPPairingKeyKeys lists names of connection additional
+//info and confirmation data that hold Google Cloud pairing key
+var ecxL2ConnectionAccepterGCPPairingKeyKeys = []string{
+	"pairingKey",
+	"gcpPairingKey",
+}
+
+//ecxL2ConnectionAccepterErrorHints maps fragments of common AWS confirmation
+//error messages to hints on how to resolve them
+var ecxL2ConnectionAccepterErrorHints = []struct {
+	fragments   []string
+	hint        string
+	withAccount bool
+}{
+	{
+		fragments: []string{"limit exceeded", "limitexceeded", "connection limit"},
+		hint:      "AWS Direct Connect connection limit was reached for the account; remove unused connections or request a limit increase from AWS",
+	},
+	{
+		fragments:   []string{"owner account", "does not belong", "not owned", "different account"},
+		hint:        "AWS credentials belong to an account different from the one authorized for the connection",
+		withAccount: true,
+	},
+	{
+		fragments: []string{"invalid security token", "unrecognizedclient", "signaturedoesnotmatch", "invalidclienttokenid", "not authorized", "accessdenied", "access denied"},
+		hint:      "AWS credentials are invalid or lack permissions to accept Direct Connect connections; verify access_key, secret_key or aws_profile",
+	},
+}
+
+//ecxL2ConnectionAccepterConfirmedFragments lists fragments of confirmation
+//error messages reported when connection is already confirmed
+var ecxL2ConnectionAccepterConfirmedFragments = []string{
+	"already confirmed",
+	"already accepted",
+	"already been accepted",
+	"no pending confirmation",
 }
 
 func resourceECXL2ConnectionAccepter() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceECXL2ConnectionAccepterCreate,
 		ReadContext:   resourceECXL2ConnectionAccepterRead,
+		UpdateContext: resourceECXL2ConnectionAccepterUpdate,
 		DeleteContext: resourceECXL2ConnectionAccepterDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -56,41 +123,113 @@ func createECXL2ConnectionAccepterResourceSchema() map[string]*schema.Schema {
 			Description:  ecxL2ConnectionAccepterDescriptions["ConnectionId"],
 		},
 		ecxL2ConnectionAccepterSchemaNames["AccessKey"]: {
-			Type:         schema.TypeString,
-			Optional:     true,
-			Computed:     true,
-			ForceNew:     true,
-			Sensitive:    true,
-			ValidateFunc: validation.StringIsNotEmpty,
-			Description:  ecxL2ConnectionAccepterDescriptions["AccessKey"],
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ForceNew:      true,
+			Sensitive:     true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{ecxL2ConnectionAccepterSchemaNames["ServiceKey"], ecxL2ConnectionAccepterSchemaNames["GCP"]},
+			Description:   ecxL2ConnectionAccepterDescriptions["AccessKey"],
 		},
 		ecxL2ConnectionAccepterSchemaNames["SecretKey"]: {
-			Type:         schema.TypeString,
-			Optional:     true,
-			Computed:     true,
-			ForceNew:     true,
-			Sensitive:    true,
-			ValidateFunc: validation.StringIsNotEmpty,
-			Description:  ecxL2ConnectionAccepterDescriptions["SecretKey"],
+			Type:          schema.TypeString,
+			Optional:      true,
+			Computed:      true,
+			ForceNew:      true,
+			Sensitive:     true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{ecxL2ConnectionAccepterSchemaNames["ServiceKey"], ecxL2ConnectionAccepterSchemaNames["GCP"]},
+			Description:   ecxL2ConnectionAccepterDescriptions["SecretKey"],
 		},
 		ecxL2ConnectionAccepterSchemaNames["Profile"]: {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ValidateFunc:  validation.StringIsNotEmpty,
+			ConflictsWith: []string{ecxL2ConnectionAccepterSchemaNames["ServiceKey"], ecxL2ConnectionAccepterSchemaNames["GCP"]},
+			Description:   ecxL2ConnectionAccepterDescriptions["Profile"],
+		},
+		ecxL2ConnectionAccepterSchemaNames["ServiceKey"]: {
 			Type:         schema.TypeString,
 			Optional:     true,
 			ForceNew:     true,
+			Sensitive:    true,
 			ValidateFunc: validation.StringIsNotEmpty,
-			Description:  ecxL2ConnectionAccepterDescriptions["Profile"],
+			Description:  ecxL2ConnectionAccepterDescriptions["ServiceKey"],
+		},
+		ecxL2ConnectionAccepterSchemaNames["GCP"]: {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			ForceNew:      true,
+			Default:       false,
+			ConflictsWith: []string{ecxL2ConnectionAccepterSchemaNames["ServiceKey"]},
+			Description:   ecxL2ConnectionAccepterDescriptions["GCP"],
+		},
+		ecxL2ConnectionAccepterSchemaNames["DeleteConnectionOnDestroy"]: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ecxL2ConnectionAccepterDescriptions["DeleteConnectionOnDestroy"],
 		},
 		ecxL2ConnectionAccepterSchemaNames["AWSConnectionID"]: {
 			Type:        schema.TypeString,
 			Computed:    true,
 			Description: ecxL2ConnectionAccepterDescriptions["AWSConnectionID"],
 		},
+		ecxL2ConnectionAccepterSchemaNames["ProviderVlan"]: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: ecxL2ConnectionAccepterDescriptions["ProviderVlan"],
+		},
+		ecxL2ConnectionAccepterSchemaNames["GCPPairingKey"]: {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: ecxL2ConnectionAccepterDescriptions["GCPPairingKey"],
+		},
 	}
 }
 
 func resourceECXL2ConnectionAccepterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
 	var diags diag.Diagnostics
+	connID := d.Get(ecxL2ConnectionAccepterSchemaNames["ConnectionId"]).(string)
+	conn, err := conf.ecx.GetL2Connection(connID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	//both sides of HA connection have to be confirmed on provider side
+	connIDs := []string{connID}
+	if redundantUUID := ecx.StringValue(conn.RedundantUUID); redundantUUID != "" {
+		connIDs = append(connIDs, redundantUUID)
+	}
+	if isECXL2ConnectionAccepterAzure(d) {
+		//Azure connections are requested with ExpressRoute service key and
+		//are provisioned by Azure without confirmation
+		if err := validateECXL2ConnectionAccepterServiceKey(conn, d.Get(ecxL2ConnectionAccepterSchemaNames["ServiceKey"]).(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(connID)
+	} else if isECXL2ConnectionAccepterGCP(d) {
+		//Google Cloud connections are requested with Partner Interconnect
+		//pairing key and are provisioned by Google Cloud without confirmation
+		d.SetId(connID)
+	} else if confirmDiags := confirmECXL2ConnectionAccepter(conf, d, conn, connIDs); confirmDiags.HasError() {
+		return confirmDiags
+	}
+	for _, id := range connIDs {
+		if _, err := createECXL2ConnectionAccepterWaitConfiguration(ctx, conf, id, d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx); err != nil {
+			return diag.Errorf("error waiting for connection %q to be provisioned on provider side: %s", id, err)
+		}
+	}
+	diags = append(diags, resourceECXL2ConnectionAccepterRead(ctx, d, m)...)
+	return diags
+}
+
+func confirmECXL2ConnectionAccepter(conf *Config, d *schema.ResourceData, conn *ecx.L2Connection, connIDs []string) diag.Diagnostics {
 	req := ecx.L2ConnectionToConfirm{}
 	creds, err := retrieveAWSCredentials(d)
 	if err != nil {
@@ -98,13 +237,20 @@ func resourceECXL2ConnectionAccepterCreate(ctx context.Context, d *schema.Resour
 	}
 	req.AccessKey = ecx.String(creds.AccessKeyID)
 	req.SecretKey = ecx.String(creds.SecretAccessKey)
-	connID := d.Get(ecxL2ConnectionAccepterSchemaNames["ConnectionId"]).(string)
-	if _, err := conf.ecx.ConfirmL2Connection(connID, req); err != nil {
-		return diag.FromErr(err)
+	for _, id := range connIDs {
+		if _, err := conf.ecx.ConfirmL2Connection(id, req); err != nil {
+			if !isECXL2ConnectionAccepterConfirmedError(err) {
+				return diag.Errorf("error confirming connection %q: %s", id, mapECXL2ConnectionAccepterError(err, conn))
+			}
+			log.Printf("[INFO] connection %q is already confirmed: %s", id, err)
+		}
+		d.SetId(ecx.StringValue(conn.UUID))
 	}
-	d.SetId(connID)
+	return nil
+}
 
-	createStateConf := &resource.StateChangeConf{
+func createECXL2ConnectionAccepterWaitConfiguration(ctx context.Context, conf *Config, connID string, timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
 		Pending: []string{
 			ecx.ConnectionStatusProvisioning,
 			ecx.ConnectionStatusPendingApproval,
@@ -112,22 +258,17 @@ func resourceECXL2ConnectionAccepterCreate(ctx context.Context, d *schema.Resour
 		Target: []string{
 			ecx.ConnectionStatusProvisioned,
 		},
-		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Timeout:    timeout,
 		Delay:      1 * time.Second,
 		MinTimeout: 1 * time.Second,
-		Refresh: func() (interface{}, string, error) {
+		Refresh: rateLimitAwareRefreshFunc(ctx, func() (interface{}, string, error) {
 			resp, err := conf.ecx.GetL2Connection(connID)
 			if err != nil {
 				return nil, "", err
 			}
 			return resp, ecx.StringValue(resp.ProviderStatus), nil
-		},
+		}, conf.retryAfter),
 	}
-	if _, err := createStateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for connection %q to be provisioned on provider side: %s", connID, err)
-	}
-	diags = append(diags, resourceECXL2ConnectionAccepterRead(ctx, d, m)...)
-	return diags
 }
 
 func resourceECXL2ConnectionAccepterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -152,44 +293,187 @@ func resourceECXL2ConnectionAccepterRead(ctx context.Context, d *schema.Resource
 	return diags
 }
 
+func resourceECXL2ConnectionAccepterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceECXL2ConnectionAccepterRead(ctx, d, m)
+}
+
 func resourceECXL2ConnectionAccepterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	log.Printf("[WARN] [equinix_ecx_l2_connection_accepter] Will not delete ECX L2 connection (%s)"+
-		"Terraform will remove this resource from the state file, however resources may remain.", d.Id())
-	return nil
+	if !d.Get(ecxL2ConnectionAccepterSchemaNames["DeleteConnectionOnDestroy"]).(bool) {
+		log.Printf("[WARN] [equinix_ecx_l2_connection_accepter] Will not delete ECX L2 connection (%s)"+
+			"Terraform will remove this resource from the state file, however resources may remain.", d.Id())
+		return nil
+	}
+	conf := m.(*Config)
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
+	return deleteECXL2ConnectionAccepterConnections(conf.ecx.GetL2Connection, conf.ecx.DeleteL2Connection, d.Id())
+}
+
+type deleteL2Connection func(uuid string) error
+
+//deleteECXL2ConnectionAccepterConnections deletes connection with a given
+//identifier, along with its redundant connection, when they still await
+//approval. Fabric API has no operation to reject connection on provider side,
+//so pending connections are deleted instead. Deletion is best effort:
+//connections that are already removed are skipped, while failures to fetch
+//or delete a connection are reported as warnings and leave it intact
+func deleteECXL2ConnectionAccepterConnections(fetchFunc getL2Connection, deleteFunc deleteL2Connection, uuid string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ids := []string{uuid}
+	for i := 0; i < len(ids); i++ {
+		id := ids[i]
+		conn, err := fetchFunc(id)
+		if err != nil {
+			if isRestNotFoundError(err) {
+				log.Printf("[WARN] [equinix_ecx_l2_connection_accepter] connection %q no longer exists", id)
+				continue
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to fetch connection %q, connection will not be deleted", id),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		if redundantUUID := ecx.StringValue(conn.RedundantUUID); redundantUUID != "" && i == 0 {
+			ids = append(ids, redundantUUID)
+		}
+		if !isECXL2ConnectionAccepterDeletable(conn) {
+			log.Printf("[WARN] [equinix_ecx_l2_connection_accepter] connection %q has provider status %q and will not be deleted; "+
+				"Terraform will remove this resource from the state file, however connection will remain", id, ecx.StringValue(conn.ProviderStatus))
+			continue
+		}
+		if err := deleteFunc(id); err != nil {
+			//IC-LAYER2-4021 = Connection already deleted
+			if restErr, ok := err.(rest.Error); ok && (isRestNotFoundError(err) || hasApplicationErrorCode(restErr.ApplicationErrors, "IC-LAYER2-4021")) {
+				continue
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to delete connection %q", id),
+				Detail:   err.Error(),
+			})
+		}
+	}
+	return diags
+}
+
+//isECXL2ConnectionAccepterDeletable checks if connection still awaits
+//approval on provider side and can be deleted on destroy
+func isECXL2ConnectionAccepterDeletable(conn *ecx.L2Connection) bool {
+	if isStringInSlice(ecx.StringValue(conn.Status), ecxL2ConnectionRemovedStatuses) {
+		return false
+	}
+	return isStringInSlice(ecx.StringValue(conn.ProviderStatus), ecxL2ConnectionAccepterDeletableStatuses)
 }
 
 func updateECXL2ConnectionAccepterResource(conn *ecx.L2Connection, d *schema.ResourceData) error {
 	if err := d.Set(ecxL2ConnectionAccepterSchemaNames["ConnectionId"], conn.UUID); err != nil {
 		return fmt.Errorf("error reading connection UUID: %s", err)
 	}
-	creds, err := retrieveAWSCredentials(d)
-	if err != nil {
-		return fmt.Errorf("error retrieving AWS credentials: %s", err)
-	}
-	if err := d.Set(ecxL2ConnectionAccepterSchemaNames["AccessKey"], creds.AccessKeyID); err != nil {
-		return fmt.Errorf("error reading AWS accessKeyID: %s", err)
-	}
-	if err := d.Set(ecxL2ConnectionAccepterSchemaNames["SecretKey"], creds.SecretAccessKey); err != nil {
-		return fmt.Errorf("error reading AWS secretAccessKey: %s", err)
-	}
-	var awsConnectionID *string
-	for _, action := range conn.Actions {
-		if ecx.StringValue(action.OperationID) != "CONFIRM_CONNECTION" {
-			continue
+	if !isECXL2ConnectionAccepterAzure(d) && !isECXL2ConnectionAccepterGCP(d) {
+		creds, err := retrieveAWSCredentials(d)
+		if err != nil {
+			return fmt.Errorf("error retrieving AWS credentials: %s", err)
 		}
-		for _, actionData := range action.RequiredData {
-			if ecx.StringValue(actionData.Key) != "awsConnectionId" {
-				continue
-			}
-			awsConnectionID = actionData.Value
+		if err := d.Set(ecxL2ConnectionAccepterSchemaNames["AccessKey"], creds.AccessKeyID); err != nil {
+			return fmt.Errorf("error reading AWS accessKeyID: %s", err)
+		}
+		if err := d.Set(ecxL2ConnectionAccepterSchemaNames["SecretKey"], creds.SecretAccessKey); err != nil {
+			return fmt.Errorf("error reading AWS secretAccessKey: %s", err)
 		}
 	}
+	awsConnectionID := getECXL2ConnectionRequiredConfirmationData(conn)["awsConnectionId"]
 	if err := d.Set(ecxL2ConnectionAccepterSchemaNames["AWSConnectionID"], awsConnectionID); err != nil {
 		return fmt.Errorf("error reading connection AWSConnectionID: %s", err)
 	}
+	if err := d.Set(ecxL2ConnectionAccepterSchemaNames["ProviderVlan"], getECXL2ConnectionAccepterProviderVlan(conn)); err != nil {
+		return fmt.Errorf("error reading connection ProviderVlan: %s", err)
+	}
+	gcpPairingKey := ""
+	if isECXL2ConnectionAccepterGCP(d) {
+		gcpPairingKey = getECXL2ConnectionAccepterGCPPairingKey(conn)
+	}
+	if err := d.Set(ecxL2ConnectionAccepterSchemaNames["GCPPairingKey"], gcpPairingKey); err != nil {
+		return fmt.Errorf("error reading connection GCPPairingKey: %s", err)
+	}
+	return nil
+}
+
+//getECXL2ConnectionAccepterProviderVlan returns VLAN assigned by service
+//provider, as reported in connection's additional info or confirmation
+//data, falling back to connection's z-side S-Tag
+func getECXL2ConnectionAccepterProviderVlan(conn *ecx.L2Connection) int {
+	values := make(map[string]string)
+	for key, value := range getECXL2ConnectionRequiredConfirmationData(conn) {
+		values[strings.ToLower(key)] = value
+	}
+	for _, info := range conn.AdditionalInfo {
+		values[strings.ToLower(ecx.StringValue(info.Name))] = ecx.StringValue(info.Value)
+	}
+	for _, key := range ecxL2ConnectionAccepterProviderVlanKeys {
+		if vlan, err := strconv.Atoi(strings.TrimSpace(values[strings.ToLower(key)])); err == nil && vlan > 0 {
+			return vlan
+		}
+	}
+	return ecx.IntValue(conn.ZSideVlanSTag)
+}
+
+func isECXL2ConnectionAccepterAzure(d *schema.ResourceData) bool {
+	return d.Get(ecxL2ConnectionAccepterSchemaNames["ServiceKey"]).(string) != ""
+}
+
+func isECXL2ConnectionAccepterGCP(d *schema.ResourceData) bool {
+	return d.Get(ecxL2ConnectionAccepterSchemaNames["GCP"]).(bool)
+}
+
+//getECXL2ConnectionAccepterGCPPairingKey returns Google Cloud pairing key
+//reported in connection's additional info or confirmation data, falling back
+//to connection's authorization key that carries pairing key on create
+func getECXL2ConnectionAccepterGCPPairingKey(conn *ecx.L2Connection) string {
+	if key := getECXL2ConnectionDetailsValue(conn, ecxL2ConnectionAccepterGCPPairingKeyKeys); key != "" {
+		return key
+	}
+	return ecx.StringValue(conn.AuthorizationKey)
+}
+
+//validateECXL2ConnectionAccepterServiceKey verifies that connection was
+//requested with a given ExpressRoute service key. Fabric API does not accept
+//service key on confirmation, so connection has to carry it as its
+//authorization key
+func validateECXL2ConnectionAccepterServiceKey(conn *ecx.L2Connection, serviceKey string) error {
+	authKey := ecx.StringValue(conn.AuthorizationKey)
+	if !strings.EqualFold(strings.TrimSpace(authKey), strings.TrimSpace(serviceKey)) {
+		return fmt.Errorf("connection %q was not requested with a given ExpressRoute service key; service key has to be "+
+			"set as connection's authorization_key when connection is created", ecx.StringValue(conn.UUID))
+	}
 	return nil
 }
 
+//mapECXL2ConnectionAccepterError enriches common AWS confirmation errors
+//with hints on how to resolve them
+func mapECXL2ConnectionAccepterError(err error, conn *ecx.L2Connection) error {
+	restErr, ok := err.(rest.Error)
+	if !ok {
+		return err
+	}
+	messages := getECXL2ConnectionAccepterErrorMessages(restErr)
+	for _, hint := range ecxL2ConnectionAccepterErrorHints {
+		for _, fragment := range hint.fragments {
+			for _, msg := range messages {
+				if !strings.Contains(msg, fragment) {
+					continue
+				}
+				if account := ecx.StringValue(conn.AuthorizationKey); hint.withAccount && account != "" {
+					return fmt.Errorf("%s (connection expects AWS account %q): %s", hint.hint, account, err)
+				}
+				return fmt.Errorf("%s: %s", hint.hint, err)
+			}
+		}
+	}
+	return err
+}
+
 func retrieveAWSCredentials(d *schema.ResourceData) (awsCredentials.Value, error) {
 	credsProviders := []awsCredentials.Provider{
 		&awsCredentials.StaticProvider{
@@ -208,3 +492,28 @@ func retrieveAWSCredentials(d *schema.ResourceData) (awsCredentials.Value, error
 	creds := awsCredentials.NewChainCredentials(credsProviders)
 	return creds.Get()
 }
+
+//isECXL2ConnectionAccepterConfirmedError checks if confirmation error
+//denotes that connection is already confirmed
+func isECXL2ConnectionAccepterConfirmedError(err error) bool {
+	restErr, ok := err.(rest.Error)
+	if !ok {
+		return false
+	}
+	for _, msg := range getECXL2ConnectionAccepterErrorMessages(restErr) {
+		for _, fragment := range ecxL2ConnectionAccepterConfirmedFragments {
+			if strings.Contains(msg, fragment) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getECXL2ConnectionAccepterErrorMessages(restErr rest.Error) []string {
+	messages := []string{strings.ToLower(restErr.Message)}
+	for _, appErr := range restErr.ApplicationErrors {
+		messages = append(messages, strings.ToLower(appErr.Message), strings.ToLower(appErr.AdditionalInfo))
+	}
+	return messages
+}
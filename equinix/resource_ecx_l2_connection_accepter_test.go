@@ -1,8 +1,12 @@
 package equinix
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/equinix/ecx-go/v2"
+	"github.com/equinix/rest-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,3 +32,174 @@ func TestRetrieveAWSCredentials_Basic(t *testing.T) {
 	assert.Equal(t, key, creds.AccessKeyID, "AccessKeyID matches")
 	assert.Equal(t, secret, creds.SecretAccessKey, "SecretAccessKey matches")
 }
+
+func TestFabricL2ConnectionAccepter_mapError(t *testing.T) {
+	//given
+	conn := &ecx.L2Connection{AuthorizationKey: ecx.String("123456789012")}
+	limitErr := rest.Error{
+		HTTPCode:          400,
+		ApplicationErrors: []rest.ApplicationError{{Code: "IC-LAYER2-ACCEPT", Message: "Connection limit exceeded"}},
+	}
+	accountErr := rest.Error{HTTPCode: 400, Message: "Connection does not belong to the owner account"}
+	unknownErr := rest.Error{HTTPCode: 500, Message: "Internal error"}
+	otherErr := fmt.Errorf("network failure")
+	//when
+	limitResult := mapECXL2ConnectionAccepterError(limitErr, conn)
+	accountResult := mapECXL2ConnectionAccepterError(accountErr, conn)
+	unknownResult := mapECXL2ConnectionAccepterError(unknownErr, conn)
+	otherResult := mapECXL2ConnectionAccepterError(otherErr, conn)
+	//then
+	assert.Contains(t, limitResult.Error(), "limit was reached", "Limit error is mapped")
+	assert.Contains(t, accountResult.Error(), "account different", "Account mismatch error is mapped")
+	assert.Contains(t, accountResult.Error(), "123456789012", "Account mismatch error contains expected account")
+	assert.Equal(t, unknownErr, unknownResult, "Unknown error is not mapped")
+	assert.Equal(t, otherErr, otherResult, "Non REST error is not mapped")
+}
+
+func TestFabricL2ConnectionAccepter_isConfirmedError(t *testing.T) {
+	//given
+	confirmedErr := rest.Error{
+		HTTPCode:          400,
+		ApplicationErrors: []rest.ApplicationError{{Code: "IC-LAYER2-ACCEPT", Message: "Connection is already confirmed"}},
+	}
+	noPendingErr := rest.Error{HTTPCode: 400, Message: "No pending confirmation for the connection"}
+	limitErr := rest.Error{
+		HTTPCode:          400,
+		ApplicationErrors: []rest.ApplicationError{{Code: "IC-LAYER2-ACCEPT", Message: "Connection limit exceeded"}},
+	}
+	otherErr := fmt.Errorf("already confirmed")
+	//when
+	confirmedResult := isECXL2ConnectionAccepterConfirmedError(confirmedErr)
+	noPendingResult := isECXL2ConnectionAccepterConfirmedError(noPendingErr)
+	limitResult := isECXL2ConnectionAccepterConfirmedError(limitErr)
+	otherResult := isECXL2ConnectionAccepterConfirmedError(otherErr)
+	//then
+	assert.True(t, confirmedResult, "Already confirmed error is detected")
+	assert.True(t, noPendingResult, "No pending confirmation error is detected")
+	assert.False(t, limitResult, "Other confirmation error is not detected")
+	assert.False(t, otherResult, "Non REST error is not detected")
+}
+
+func TestFabricL2ConnectionAccepter_getProviderVlan(t *testing.T) {
+	//given
+	withInfo := &ecx.L2Connection{
+		ZSideVlanSTag:  ecx.Int(100),
+		AdditionalInfo: []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String("providerVLAN"), Value: ecx.String("1234")}},
+	}
+	withActionData := &ecx.L2Connection{
+		Actions: []ecx.L2ConnectionAction{
+			{
+				OperationID:  ecx.String(ecxL2ConnectionActionConfirmConnection),
+				RequiredData: []ecx.L2ConnectionActionData{{Key: ecx.String("vlan"), Value: ecx.String("321")}},
+			},
+		},
+	}
+	withZSideVlan := &ecx.L2Connection{
+		ZSideVlanSTag:  ecx.Int(100),
+		AdditionalInfo: []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String("vlanId"), Value: ecx.String("n/a")}},
+	}
+	//when
+	infoVlan := getECXL2ConnectionAccepterProviderVlan(withInfo)
+	actionDataVlan := getECXL2ConnectionAccepterProviderVlan(withActionData)
+	zSideVlan := getECXL2ConnectionAccepterProviderVlan(withZSideVlan)
+	emptyVlan := getECXL2ConnectionAccepterProviderVlan(&ecx.L2Connection{})
+	//then
+	assert.Equal(t, 1234, infoVlan, "VLAN from additional info is returned")
+	assert.Equal(t, 321, actionDataVlan, "VLAN from confirmation data is returned")
+	assert.Equal(t, 100, zSideVlan, "Z side VLAN is returned when provider VLAN is not reported")
+	assert.Equal(t, 0, emptyVlan, "Zero is returned when VLAN is not known")
+}
+
+func TestFabricL2ConnectionAccepter_validateServiceKey(t *testing.T) {
+	//given
+	serviceKey := randString(36)
+	conn := &ecx.L2Connection{UUID: ecx.String(randString(36)), AuthorizationKey: ecx.String(serviceKey)}
+	//when
+	matchingErr := validateECXL2ConnectionAccepterServiceKey(conn, serviceKey)
+	otherErr := validateECXL2ConnectionAccepterServiceKey(conn, randString(36))
+	//then
+	assert.Nil(t, matchingErr, "Service key used to request connection passes validation")
+	assert.NotNil(t, otherErr, "Other service key fails validation")
+}
+
+func TestFabricL2ConnectionAccepter_getGCPPairingKey(t *testing.T) {
+	//given
+	withActionData := &ecx.L2Connection{
+		AuthorizationKey: ecx.String("auth-key/us-west1/1"),
+		Actions: []ecx.L2ConnectionAction{
+			{
+				OperationID: ecx.String(ecxL2ConnectionActionConfirmConnection),
+				RequiredData: []ecx.L2ConnectionActionData{
+					{Key: ecx.String("pairingKey"), Value: ecx.String("pairing-key/us-west1/1")},
+				},
+			},
+		},
+	}
+	withInfo := &ecx.L2Connection{
+		AdditionalInfo: []ecx.L2ConnectionAdditionalInfo{
+			{Name: ecx.String("gcpPairingKey"), Value: ecx.String("info-key/us-west1/2")},
+		},
+	}
+	withAuthKey := &ecx.L2Connection{AuthorizationKey: ecx.String("auth-key/us-west1/1")}
+	//when
+	actionDataKey := getECXL2ConnectionAccepterGCPPairingKey(withActionData)
+	infoKey := getECXL2ConnectionAccepterGCPPairingKey(withInfo)
+	authKey := getECXL2ConnectionAccepterGCPPairingKey(withAuthKey)
+	emptyKey := getECXL2ConnectionAccepterGCPPairingKey(&ecx.L2Connection{})
+	//then
+	assert.Equal(t, "pairing-key/us-west1/1", actionDataKey, "Pairing key from confirmation data is returned")
+	assert.Equal(t, "info-key/us-west1/2", infoKey, "Pairing key from additional info is returned")
+	assert.Equal(t, "auth-key/us-west1/1", authKey, "Authorization key is returned when pairing key is not reported")
+	assert.Empty(t, emptyKey, "Empty string is returned when pairing key is not known")
+}
+
+func TestFabricL2ConnectionAccepter_isDeletable(t *testing.T) {
+	//given
+	pending := &ecx.L2Connection{Status: ecx.String(ecx.ConnectionStatusProvisioned), ProviderStatus: ecx.String(ecx.ConnectionStatusPendingApproval)}
+	provisioned := &ecx.L2Connection{Status: ecx.String(ecx.ConnectionStatusProvisioned), ProviderStatus: ecx.String(ecx.ConnectionStatusProvisioned)}
+	deleted := &ecx.L2Connection{Status: ecx.String(ecx.ConnectionStatusDeleted), ProviderStatus: ecx.String(ecx.ConnectionStatusPendingApproval)}
+	//when
+	pendingResult := isECXL2ConnectionAccepterDeletable(pending)
+	provisionedResult := isECXL2ConnectionAccepterDeletable(provisioned)
+	deletedResult := isECXL2ConnectionAccepterDeletable(deleted)
+	//then
+	assert.True(t, pendingResult, "Connection pending approval is deleted")
+	assert.False(t, provisionedResult, "Provisioned connection is not deleted")
+	assert.False(t, deletedResult, "Already deleted connection is not deleted")
+}
+
+func TestFabricL2ConnectionAccepter_deleteConnections(t *testing.T) {
+	//given
+	primaryID := randString(36)
+	secondaryID := randString(36)
+	unavailableID := randString(36)
+	conns := map[string]*ecx.L2Connection{
+		primaryID: {UUID: ecx.String(primaryID), RedundantUUID: ecx.String(secondaryID),
+			Status: ecx.String(ecx.ConnectionStatusProvisioned), ProviderStatus: ecx.String(ecx.ConnectionStatusPendingApproval)},
+		unavailableID: {UUID: ecx.String(unavailableID), RedundantUUID: ecx.String(randString(36)),
+			Status: ecx.String(ecx.ConnectionStatusProvisioned), ProviderStatus: ecx.String(ecx.ConnectionStatusPendingApproval)},
+	}
+	fetchFunc := func(uuid string) (*ecx.L2Connection, error) {
+		if conn, ok := conns[uuid]; ok {
+			return conn, nil
+		}
+		if uuid == secondaryID {
+			return nil, rest.Error{HTTPCode: http.StatusNotFound}
+		}
+		return nil, rest.Error{HTTPCode: http.StatusInternalServerError}
+	}
+	var deleted []string
+	deleteFunc := func(uuid string) error {
+		deleted = append(deleted, uuid)
+		return nil
+	}
+	//when
+	diags := deleteECXL2ConnectionAccepterConnections(fetchFunc, deleteFunc, primaryID)
+	unavailableDiags := deleteECXL2ConnectionAccepterConnections(fetchFunc, deleteFunc, unavailableID)
+	//then
+	assert.False(t, diags.HasError(), "Removed secondary connection does not cause error")
+	assert.Empty(t, diags, "Removed secondary connection does not cause warning")
+	assert.False(t, unavailableDiags.HasError(), "Secondary connection fetch failure does not cause error")
+	assert.Len(t, unavailableDiags, 1, "Secondary connection fetch failure is reported as warning")
+	assert.Equal(t, []string{primaryID, unavailableID}, deleted, "Pending connections are deleted")
+}
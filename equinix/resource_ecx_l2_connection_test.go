@@ -1,10 +1,15 @@
 package equinix
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/equinix/ecx-go/v2"
+	"github.com/equinix/ne-go"
+	"github.com/equinix/rest-go"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -79,7 +84,7 @@ func TestFabricL2Connection_updateResourceData(t *testing.T) {
 		VlanSTag:            ecx.Int(randInt(2000)),
 		VlanCTag:            ecx.Int(randInt(2000)),
 		NamedTag:            ecx.String(randString(100)),
-		AdditionalInfo:      []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String(randString(10)), Value: ecx.String(randString(10))}},
+		AdditionalInfo:      []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String("bgpAsn"), Value: ecx.String("64512")}},
 		ZSidePortUUID:       ecx.String(randString(36)),
 		ZSideVlanCTag:       ecx.Int(randInt(2000)),
 		ZSideVlanSTag:       ecx.Int(randInt(2000)),
@@ -99,6 +104,8 @@ func TestFabricL2Connection_updateResourceData(t *testing.T) {
 	assert.Equal(t, ecx.StringValue(input.ProfileUUID), d.Get(ecxL2ConnectionSchemaNames["ProfileUUID"]), "ProfileUUID matches")
 	assert.Equal(t, ecx.IntValue(input.Speed), d.Get(ecxL2ConnectionSchemaNames["Speed"]), "Speed matches")
 	assert.Equal(t, ecx.StringValue(input.SpeedUnit), d.Get(ecxL2ConnectionSchemaNames["SpeedUnit"]), "SpeedUnit matches")
+	assert.Equal(t, "50 MB", d.Get(ecxL2ConnectionSchemaNames["SpeedHuman"]), "SpeedHuman matches")
+	assert.Equal(t, 50, d.Get(ecxL2ConnectionSchemaNames["BandwidthInMbps"]), "BandwidthInMbps matches")
 	assert.Equal(t, ecx.StringValue(input.Status), d.Get(ecxL2ConnectionSchemaNames["Status"]), "Status matches")
 	assert.Equal(t, ecx.StringValue(input.ProviderStatus), d.Get(ecxL2ConnectionSchemaNames["ProviderStatus"]), "ProviderStatus matches")
 	assert.Equal(t, input.Notifications, expandSetToStringList(d.Get(ecxL2ConnectionSchemaNames["Notifications"]).(*schema.Set)), "Notifications matches")
@@ -112,12 +119,131 @@ func TestFabricL2Connection_updateResourceData(t *testing.T) {
 	assert.Equal(t, input.AdditionalInfo, expandECXL2ConnectionAdditionalInfo(d.Get(ecxL2ConnectionSchemaNames["AdditionalInfo"]).(*schema.Set)), "AdditionalInfo matches")
 	assert.Equal(t, ecx.StringValue(input.ZSidePortUUID), d.Get(ecxL2ConnectionSchemaNames["ZSidePortUUID"]), "ZSidePortUUID matches")
 	assert.Equal(t, ecx.IntValue(input.ZSideVlanCTag), d.Get(ecxL2ConnectionSchemaNames["ZSideVlanCTag"]), "ZSideVlanCTag matches")
+	assert.Equal(t, formatECXL2ConnectionVlan(input.ZSideVlanSTag, input.ZSideVlanCTag), d.Get(ecxL2ConnectionSchemaNames["ZSideVlan"]), "ZSideVlan matches")
 	assert.Equal(t, ecx.IntValue(input.ZSideVlanSTag), d.Get(ecxL2ConnectionSchemaNames["ZSideVlanSTag"]), "ZSideVlanSTag matches")
 	assert.Equal(t, ecx.StringValue(input.SellerRegion), d.Get(ecxL2ConnectionSchemaNames["SellerRegion"]), "SellerRegion matches")
 	assert.Equal(t, ecx.StringValue(input.SellerMetroCode), d.Get(ecxL2ConnectionSchemaNames["SellerMetroCode"]), "SellerMetroCode matches")
 	assert.Equal(t, ecx.StringValue(input.AuthorizationKey), d.Get(ecxL2ConnectionSchemaNames["AuthorizationKey"]), "AuthorizationKey matches")
 	assert.Equal(t, ecx.StringValue(input.RedundantUUID), d.Get(ecxL2ConnectionSchemaNames["RedundantUUID"]), "RedundantUUID matches")
 	assert.Equal(t, ecx.StringValue(input.RedundancyType), d.Get(ecxL2ConnectionSchemaNames["RedundancyType"]), "RedundancyType matches")
+	assert.Equal(t, "64512", d.Get(ecxL2ConnectionSchemaNames["BGPASN"]), "BGPASN matches")
+	assert.Equal(t, ecxL2ConnectionLifecycleStageActive, d.Get(ecxL2ConnectionSchemaNames["LifecycleStage"]), "LifecycleStage matches")
+}
+
+func TestFabricL2Connection_updateResourceData_profileZSidePort(t *testing.T) {
+	//given
+	profileUUID := randString(36)
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), map[string]interface{}{
+		ecxL2ConnectionSchemaNames["ProfileUUID"]: profileUUID,
+	})
+	input := &ecx.L2Connection{
+		UUID:          ecx.String(randString(36)),
+		ProfileUUID:   ecx.String(profileUUID),
+		ZSidePortUUID: ecx.String(randString(36)),
+	}
+	//when
+	err := updateECXL2ConnectionResource(input, nil, d)
+	//then
+	assert.Nil(t, err, "Update of resource data does not return error")
+	assert.Equal(t, ecx.StringValue(input.ZSidePortUUID), d.Get(ecxL2ConnectionSchemaNames["ZSidePortUUID"]), "Platform assigned ZSidePortUUID is populated")
+}
+
+func TestFabricL2Connection_updateResourceData_externalNotifications(t *testing.T) {
+	//given
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), make(map[string]interface{}))
+	imported := &ecx.L2Connection{
+		UUID:          ecx.String(randString(36)),
+		Notifications: []string{"john@equinix.com"},
+	}
+	changed := &ecx.L2Connection{
+		UUID:          imported.UUID,
+		Notifications: []string{"john@equinix.com", "marry@equinix.com"},
+	}
+	//when
+	importErr := updateECXL2ConnectionResource(imported, nil, d)
+	importedNotifications := expandSetToStringList(d.Get(ecxL2ConnectionSchemaNames["Notifications"]).(*schema.Set))
+	refreshErr := updateECXL2ConnectionResource(changed, nil, d)
+	refreshedNotifications := expandSetToStringList(d.Get(ecxL2ConnectionSchemaNames["Notifications"]).(*schema.Set))
+	//then
+	assert.Nil(t, importErr, "Update of resource data on import does not return error")
+	assert.Equal(t, imported.Notifications, importedNotifications, "Notifications are populated on import")
+	assert.Nil(t, refreshErr, "Update of resource data on refresh does not return error")
+	assert.Equal(t, imported.Notifications, refreshedNotifications, "Notifications changed externally are not populated on refresh")
+}
+
+func TestFabricL2Connection_updateResourceData_secondaryStatus(t *testing.T) {
+	//given
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), make(map[string]interface{}))
+	primary := &ecx.L2Connection{
+		UUID:   ecx.String(randString(36)),
+		Status: ecx.String(ecx.ConnectionStatusProvisioned),
+	}
+	secondary := &ecx.L2Connection{
+		UUID:           ecx.String(randString(36)),
+		Status:         ecx.String(ecx.ConnectionStatusProvisioning),
+		ProviderStatus: ecx.String(ecx.ConnectionStatusPendingApproval),
+	}
+	//when
+	err := updateECXL2ConnectionResource(primary, secondary, d)
+	//then
+	assert.Nil(t, err, "Update of resource data does not return error")
+	assert.Equal(t, ecx.StringValue(secondary.UUID), d.Get(ecxL2ConnectionSchemaNames["SecondaryUUID"]), "SecondaryUUID matches")
+	assert.Equal(t, ecx.StringValue(secondary.Status), d.Get(ecxL2ConnectionSchemaNames["SecondaryStatus"]), "SecondaryStatus matches")
+	assert.Equal(t, ecx.StringValue(secondary.ProviderStatus), d.Get(ecxL2ConnectionSchemaNames["SecondaryProviderStatus"]), "SecondaryProviderStatus matches")
+}
+
+func TestFabricL2Connection_updateResourceData_secondaryRemoved(t *testing.T) {
+	//given
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), make(map[string]interface{}))
+	secondary := &ecx.L2Connection{
+		UUID:   ecx.String(randString(36)),
+		Status: ecx.String(ecx.ConnectionStatusProvisioned),
+	}
+	primary := &ecx.L2Connection{
+		UUID:          ecx.String(randString(36)),
+		RedundantUUID: secondary.UUID,
+	}
+	standalone := &ecx.L2Connection{
+		UUID: primary.UUID,
+	}
+	//when
+	err := updateECXL2ConnectionResource(primary, secondary, d)
+	failedFetchErr := updateECXL2ConnectionResource(primary, nil, d)
+	failedFetchUUID := d.Get(ecxL2ConnectionSchemaNames["SecondaryUUID"])
+	removedErr := updateECXL2ConnectionResource(standalone, nil, d)
+	//then
+	assert.Nil(t, err, "Update of resource data does not return error")
+	assert.Nil(t, failedFetchErr, "Update of resource data without fetched secondary does not return error")
+	assert.Equal(t, ecx.StringValue(secondary.UUID), failedFetchUUID, "SecondaryUUID is kept when secondary was not fetched")
+	assert.Nil(t, removedErr, "Update of resource data without secondary does not return error")
+	assert.Empty(t, d.Get(ecxL2ConnectionSchemaNames["SecondaryUUID"]), "SecondaryUUID is cleared when secondary is removed")
+	assert.Empty(t, d.Get(ecxL2ConnectionSchemaNames["SecondaryStatus"]), "SecondaryStatus is cleared when secondary is removed")
+}
+
+func TestFabricL2Connection_vlanTagValidation(t *testing.T) {
+	//given
+	resourceSchema := createECXL2ConnectionResourceSchema()
+	secondarySchema := resourceSchema[ecxL2ConnectionSchemaNames["SecondaryConnection"]].Elem.(*schema.Resource).Schema
+	validateFuncs := map[string]schema.SchemaValidateFunc{
+		"VlanSTag":          resourceSchema[ecxL2ConnectionSchemaNames["VlanSTag"]].ValidateFunc,
+		"VlanCTag":          resourceSchema[ecxL2ConnectionSchemaNames["VlanCTag"]].ValidateFunc,
+		"ZSideVlanSTag":     resourceSchema[ecxL2ConnectionSchemaNames["ZSideVlanSTag"]].ValidateFunc,
+		"ZSideVlanCTag":     resourceSchema[ecxL2ConnectionSchemaNames["ZSideVlanCTag"]].ValidateFunc,
+		"SecondaryVlanSTag": secondarySchema[ecxL2ConnectionSchemaNames["VlanSTag"]].ValidateFunc,
+		"SecondaryVlanCTag": secondarySchema[ecxL2ConnectionSchemaNames["VlanCTag"]].ValidateFunc,
+	}
+	input := []int{1, 2, 4093, 4094, 4095}
+	expected := []bool{false, true, true, true, false}
+	for name, validateFunc := range validateFuncs {
+		//when
+		result := make([]bool, len(input))
+		for i := range input {
+			_, errs := validateFunc(input[i], name)
+			result[i] = len(errs) == 0
+		}
+		//then
+		assert.Equal(t, expected, result, "%s validation results match", name)
+	}
 }
 
 func TestFabricL2Connection_flattenSecondary(t *testing.T) {
@@ -168,6 +294,7 @@ func TestFabricL2Connection_flattenSecondary(t *testing.T) {
 			ecxL2ConnectionSchemaNames["AuthorizationKey"]:  input.AuthorizationKey,
 			ecxL2ConnectionSchemaNames["RedundantUUID"]:     input.RedundantUUID,
 			ecxL2ConnectionSchemaNames["RedundancyType"]:    input.RedundancyType,
+			ecxL2ConnectionSchemaNames["IsPrimary"]:         false,
 		},
 	}
 
@@ -241,6 +368,177 @@ func TestFabricL2Connection_flattenAdditionalInfo(t *testing.T) {
 	assert.Equal(t, expected, out, "Output matches expected result")
 }
 
+func TestFabricL2Connection_flattenCloudDetails(t *testing.T) {
+	//given
+	input := &ecx.L2Connection{
+		AdditionalInfo: []ecx.L2ConnectionAdditionalInfo{
+			{Name: ecx.String("bgpAsn"), Value: ecx.String("64512")},
+		},
+		Actions: []ecx.L2ConnectionAction{
+			{
+				OperationID: ecx.String("CONFIRM_CONNECTION"),
+				RequiredData: []ecx.L2ConnectionActionData{
+					{Key: ecx.String("awsConnectionId"), Value: ecx.String("dxcon-fgq1p2yx")},
+					{Key: ecx.String("vlan"), Value: ecx.String("1012")},
+				},
+			},
+		},
+	}
+	expected := []interface{}{
+		map[string]interface{}{
+			ecxL2ConnectionCloudDetailsSchemaNames["ConnectionID"]: "dxcon-fgq1p2yx",
+			ecxL2ConnectionCloudDetailsSchemaNames["VLAN"]:         "1012",
+			ecxL2ConnectionCloudDetailsSchemaNames["ASN"]:          "64512",
+		},
+	}
+	//when
+	out := flattenECXL2ConnectionCloudDetails(input)
+	//then
+	assert.Equal(t, expected, out, "Output matches expected result")
+	assert.Equal(t, []interface{}{}, flattenECXL2ConnectionCloudDetails(&ecx.L2Connection{}), "Empty output for connection without details")
+}
+
+func TestFabricL2Connection_getRequiredConfirmationData(t *testing.T) {
+	//given
+	input := &ecx.L2Connection{
+		Actions: []ecx.L2ConnectionAction{
+			{
+				OperationID: ecx.String(ecxL2ConnectionActionConfirmConnection),
+				RequiredData: []ecx.L2ConnectionActionData{
+					{Key: ecx.String("awsConnectionId"), Value: ecx.String("dxcon-fgabc123")},
+					{Key: ecx.String("serviceKey")},
+				},
+			},
+			{
+				OperationID: ecx.String("UPDATE_AUTHORIZATION_KEY"),
+				RequiredData: []ecx.L2ConnectionActionData{
+					{Key: ecx.String("authorizationKey"), Value: ecx.String(randString(12))},
+				},
+			},
+		},
+	}
+	expected := map[string]string{
+		"awsConnectionId": "dxcon-fgabc123",
+		"serviceKey":      "",
+	}
+	//when
+	result := getECXL2ConnectionRequiredConfirmationData(input)
+	//then
+	assert.Equal(t, expected, result, "Required confirmation data matches expected result")
+}
+
+func TestFabricL2Connection_createFromResourceData_zSideServiceKey(t *testing.T) {
+	//given
+	serviceKey := "9a3f1e4b-2c5d-4e6f-8a7b-0c1d2e3f4a5b"
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), map[string]interface{}{
+		ecxL2ConnectionSchemaNames["NamedTag"]:        ecxL2ConnectionNamedTagManual,
+		ecxL2ConnectionSchemaNames["ZSideServiceKey"]: serviceKey,
+	})
+	//when
+	primary, _ := createECXL2Connections(d)
+	//then
+	assert.Equal(t, []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String(ecxL2ConnectionZSideServiceKeyInfoName), Value: ecx.String(serviceKey)}},
+		primary.AdditionalInfo, "Service key is sent as additional info")
+}
+
+func TestFabricL2Connection_extractAdditionalInfo(t *testing.T) {
+	//given
+	other := ecx.L2ConnectionAdditionalInfo{Name: ecx.String("global"), Value: ecx.String("false")}
+	input := []ecx.L2ConnectionAdditionalInfo{
+		other,
+		{Name: ecx.String(ecxL2ConnectionZSideServiceKeyInfoName), Value: ecx.String(randString(36))},
+	}
+	//when
+	value, remaining := extractECXL2ConnectionAdditionalInfo(input, ecxL2ConnectionZSideServiceKeyInfoName)
+	missing, _ := extractECXL2ConnectionAdditionalInfo(remaining, ecxL2ConnectionZSideServiceKeyInfoName)
+	//then
+	assert.Equal(t, input[1].Value, value, "Extracted value matches")
+	assert.Equal(t, []ecx.L2ConnectionAdditionalInfo{other}, remaining, "Remaining additional info matches")
+	assert.Nil(t, missing, "Missing additional info is nil")
+}
+
+func TestFabricL2Connection_validateZSideServiceKey(t *testing.T) {
+	//given
+	serviceKey := randString(36)
+	duplicated := []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String(ecxL2ConnectionZSideServiceKeyInfoName), Value: ecx.String(serviceKey)}}
+	//when
+	manualErr := validateECXL2ConnectionZSideServiceKey(serviceKey, ecxL2ConnectionNamedTagManual, nil)
+	privateErr := validateECXL2ConnectionZSideServiceKey(serviceKey, "Private", nil)
+	duplicatedErr := validateECXL2ConnectionZSideServiceKey(serviceKey, ecxL2ConnectionNamedTagManual, duplicated)
+	withoutKeyErr := validateECXL2ConnectionZSideServiceKey("", "Private", duplicated)
+	//then
+	assert.Nil(t, manualErr, "Service key with Manual named tag is valid")
+	assert.NotNil(t, privateErr, "Service key with other named tag is invalid")
+	assert.NotNil(t, duplicatedErr, "Service key duplicated in additional info is invalid")
+	assert.Nil(t, withoutKeyErr, "Connection without service key is not validated")
+}
+
+func TestFabricL2Connection_createFromResourceData_peeringRoutes(t *testing.T) {
+	//given
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), make(map[string]interface{}))
+	d.Set(ecxL2ConnectionSchemaNames["NamedTag"], ecxL2ConnectionNamedTagMicrosoft)
+	d.Set(ecxL2ConnectionSchemaNames["PeeringPrefixes"], []string{"203.0.113.0/30"})
+	d.Set(ecxL2ConnectionSchemaNames["AdvertisedRoutes"], []string{"198.51.100.0/24"})
+	//when
+	primary, _ := createECXL2Connections(d)
+	//then
+	prefixes, remaining := extractECXL2ConnectionAdditionalInfo(primary.AdditionalInfo, ecxL2ConnectionPeeringPrefixesInfoName)
+	routes, _ := extractECXL2ConnectionAdditionalInfo(remaining, ecxL2ConnectionAdvertisedRoutesInfoName)
+	assert.Equal(t, "203.0.113.0/30", ecx.StringValue(prefixes), "Peering prefixes are sent as additional info")
+	assert.Equal(t, "198.51.100.0/24", ecx.StringValue(routes), "Advertised routes are sent as additional info")
+}
+
+func TestFabricL2Connection_validatePeeringRoutes(t *testing.T) {
+	//given
+	prefixes := []string{"203.0.113.0/30", "203.0.113.4/30"}
+	duplicated := []ecx.L2ConnectionAdditionalInfo{{Name: ecx.String(ecxL2ConnectionAdvertisedRoutesInfoName), Value: ecx.String("198.51.100.0/24")}}
+	//when
+	microsoftErr := validateECXL2ConnectionPeeringRoutes(prefixes, nil, ecxL2ConnectionNamedTagMicrosoft, nil)
+	publicErr := validateECXL2ConnectionPeeringRoutes(nil, prefixes, ecxL2ConnectionNamedTagPublic, nil)
+	privateErr := validateECXL2ConnectionPeeringRoutes(prefixes, nil, "Private", nil)
+	duplicatedErr := validateECXL2ConnectionPeeringRoutes(nil, prefixes, ecxL2ConnectionNamedTagMicrosoft, duplicated)
+	withoutRoutesErr := validateECXL2ConnectionPeeringRoutes(nil, nil, "Private", duplicated)
+	//then
+	assert.Nil(t, microsoftErr, "Peering prefixes with Microsoft named tag are valid")
+	assert.Nil(t, publicErr, "Advertised routes with Public named tag are valid")
+	assert.NotNil(t, privateErr, "Peering prefixes with other named tag are invalid")
+	assert.NotNil(t, duplicatedErr, "Advertised routes duplicated in additional info are invalid")
+	assert.Nil(t, withoutRoutesErr, "Connection without peering routes is not validated")
+}
+
+func TestFabricL2Connection_splitInfoList(t *testing.T) {
+	//given
+	input := []string{"203.0.113.0/30,198.51.100.0/24", " 203.0.113.0/30 , ", ""}
+	expected := [][]string{{"203.0.113.0/30", "198.51.100.0/24"}, {"203.0.113.0/30"}, {}}
+	//when
+	result := make([][]string, len(input))
+	for i := range input {
+		result[i] = splitECXL2ConnectionInfoList(input[i])
+	}
+	//then
+	assert.Equal(t, expected, result, "Split values match")
+}
+
+func TestFabricL2Connection_normalizeAdditionalInfoNames(t *testing.T) {
+	//given
+	fetched := []ecx.L2ConnectionAdditionalInfo{
+		{Name: ecx.String("BGP_ASN"), Value: ecx.String("64512")},
+		{Name: ecx.String("providerVlan"), Value: ecx.String("100")},
+	}
+	previous := []ecx.L2ConnectionAdditionalInfo{
+		{Name: ecx.String("bgp_asn"), Value: ecx.String("64512")},
+	}
+	expected := []ecx.L2ConnectionAdditionalInfo{
+		{Name: ecx.String("bgp_asn"), Value: ecx.String("64512")},
+		{Name: ecx.String("providerVlan"), Value: ecx.String("100")},
+	}
+	//when
+	result := normalizeECXL2ConnectionAdditionalInfoNames(fetched, previous)
+	//then
+	assert.Equal(t, expected, result, "Names are normalized to previously known casing")
+	assert.Equal(t, "BGP_ASN", ecx.StringValue(fetched[0].Name), "Fetched additional infos are not modified")
+}
+
 func TestFabricL2Connection_expandAdditionalInfo(t *testing.T) {
 	f := func(i interface{}) int {
 		str := fmt.Sprintf("%v", i)
@@ -267,10 +565,808 @@ func TestFabricL2Connection_expandAdditionalInfo(t *testing.T) {
 	assert.Equal(t, expected, out, "Output matches expected result")
 }
 
+func TestFabricL2Connection_formatSpeed(t *testing.T) {
+	//given
+	input := []struct {
+		speed     *int
+		speedUnit *string
+		expected  string
+	}{
+		{ecx.Int(500), ecx.String("MB"), "500 MB"},
+		{ecx.Int(10), ecx.String("GB"), "10 GB"},
+		{nil, ecx.String("GB"), ""},
+		{ecx.Int(10), nil, ""},
+	}
+	for _, in := range input {
+		//when
+		out := formatECXL2ConnectionSpeed(in.speed, in.speedUnit)
+		//then
+		assert.Equal(t, in.expected, out, "Formatted speed matches")
+	}
+}
+
+func TestFabricL2Connection_formatVlan(t *testing.T) {
+	//given
+	input := []struct {
+		sTag     *int
+		cTag     *int
+		expected string
+	}{
+		{ecx.Int(100), ecx.Int(200), "100.200"},
+		{ecx.Int(100), nil, "100"},
+		{ecx.Int(100), ecx.Int(0), "100"},
+		{nil, nil, ""},
+	}
+	for _, in := range input {
+		//when
+		out := formatECXL2ConnectionVlan(in.sTag, in.cTag)
+		//then
+		assert.Equal(t, in.expected, out, "Formatted VLAN matches")
+	}
+}
+
+func TestFabricL2Connection_validateZSidePort(t *testing.T) {
+	//given
+	portUUID := randString(36)
+	fetchFunc := func() ([]ecx.Port, error) {
+		return []ecx.Port{{UUID: ecx.String(portUUID)}}, nil
+	}
+	owned := &ecx.L2Connection{ZSidePortUUID: ecx.String(portUUID)}
+	foreign := &ecx.L2Connection{ZSidePortUUID: ecx.String(randString(36))}
+	withProfile := &ecx.L2Connection{ZSidePortUUID: ecx.String(randString(36)), ProfileUUID: ecx.String(randString(36))}
+	//when
+	ownedErr := validateECXL2ConnectionZSidePort(fetchFunc, owned)
+	foreignErr := validateECXL2ConnectionZSidePort(fetchFunc, foreign)
+	withProfileErr := validateECXL2ConnectionZSidePort(fetchFunc, withProfile)
+	//then
+	assert.Nil(t, ownedErr, "Port owned by account passes validation")
+	assert.NotNil(t, foreignErr, "Port not owned by account fails validation")
+	assert.Nil(t, withProfileErr, "Port is not validated for profile based connection")
+}
+
+func TestFabricL2Connection_fillSpeedFromProfile(t *testing.T) {
+	//given
+	singleBandUUID := randString(36)
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		profile := &ecx.L2ServiceProfile{
+			UUID: ecx.String(uuid),
+			SpeedBands: []ecx.L2ServiceProfileSpeedBand{
+				{Speed: ecx.Int(50), SpeedUnit: ecx.String("MB")},
+			},
+		}
+		if uuid != singleBandUUID {
+			profile.SpeedBands = append(profile.SpeedBands, ecx.L2ServiceProfileSpeedBand{Speed: ecx.Int(1), SpeedUnit: ecx.String("GB")})
+		}
+		return profile, nil
+	}
+	singleBand := &ecx.L2Connection{ProfileUUID: ecx.String(singleBandUUID)}
+	multipleBands := &ecx.L2Connection{ProfileUUID: ecx.String(randString(36))}
+	withSpeed := &ecx.L2Connection{ProfileUUID: ecx.String(randString(36)), Speed: ecx.Int(200), SpeedUnit: ecx.String("MB")}
+	withoutProfile := &ecx.L2Connection{}
+	//when
+	singleBandErr := fillECXL2ConnectionSpeedFromProfile(fetchFunc, singleBand)
+	multipleBandsErr := fillECXL2ConnectionSpeedFromProfile(fetchFunc, multipleBands)
+	withSpeedErr := fillECXL2ConnectionSpeedFromProfile(fetchFunc, withSpeed)
+	withoutProfileErr := fillECXL2ConnectionSpeedFromProfile(fetchFunc, withoutProfile)
+	//then
+	assert.Nil(t, singleBandErr, "Single band profile speed resolution does not return error")
+	assert.Equal(t, 50, ecx.IntValue(singleBand.Speed), "Speed matches profile speed band")
+	assert.Equal(t, "MB", ecx.StringValue(singleBand.SpeedUnit), "SpeedUnit matches profile speed band")
+	assert.NotNil(t, multipleBandsErr, "Multiple bands profile speed resolution returns error")
+	assert.Nil(t, withSpeedErr, "Connection with speed does not need speed resolution")
+	assert.Equal(t, 200, ecx.IntValue(withSpeed.Speed), "Given speed is not changed")
+	assert.NotNil(t, withoutProfileErr, "Connection without speed and profile returns error")
+}
+
+func TestFabricL2Connection_validateProfileState(t *testing.T) {
+	//given
+	activeUUID := randString(36)
+	deletedUUID := randString(36)
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		switch uuid {
+		case activeUUID:
+			return &ecx.L2ServiceProfile{UUID: ecx.String(uuid)}, nil
+		case deletedUUID:
+			return &ecx.L2ServiceProfile{UUID: ecx.String(uuid), State: ecx.String("DELETED")}, nil
+		}
+		return nil, fmt.Errorf("profile not found")
+	}
+	//when
+	activeErr := validateECXL2ConnectionProfileState(fetchFunc, activeUUID)
+	deletedErr := validateECXL2ConnectionProfileState(fetchFunc, deletedUUID)
+	unknownErr := validateECXL2ConnectionProfileState(fetchFunc, randString(36))
+	withoutProfileErr := validateECXL2ConnectionProfileState(fetchFunc, "")
+	//then
+	assert.Nil(t, activeErr, "Active profile passes validation")
+	assert.NotNil(t, deletedErr, "Deleted profile fails validation")
+	assert.Nil(t, unknownErr, "Unavailable profile is not validated")
+	assert.Nil(t, withoutProfileErr, "Connection without profile is not validated")
+}
+
+func TestFabricL2Connection_validateRedundancyRequirement(t *testing.T) {
+	//given
+	redundantUUID := randString(36)
+	singleUUID := randString(36)
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		switch uuid {
+		case redundantUUID:
+			return &ecx.L2ServiceProfile{UUID: ecx.String(uuid), RequiredRedundancy: ecx.Bool(true)}, nil
+		case singleUUID:
+			return &ecx.L2ServiceProfile{UUID: ecx.String(uuid), RequiredRedundancy: ecx.Bool(false)}, nil
+		}
+		return nil, fmt.Errorf("profile not found")
+	}
+	//when
+	redundantErr := validateECXL2ConnectionRedundancyRequirement(fetchFunc, redundantUUID, true)
+	redundantSingleErr := validateECXL2ConnectionRedundancyRequirement(fetchFunc, redundantUUID, false)
+	singleErr := validateECXL2ConnectionRedundancyRequirement(fetchFunc, singleUUID, false)
+	unknownErr := validateECXL2ConnectionRedundancyRequirement(fetchFunc, randString(36), false)
+	withoutProfileErr := validateECXL2ConnectionRedundancyRequirement(fetchFunc, "", false)
+	//then
+	assert.Nil(t, redundantErr, "Redundant connection to profile requiring redundancy passes validation")
+	assert.NotNil(t, redundantSingleErr, "Single connection to profile requiring redundancy fails validation")
+	assert.Nil(t, singleErr, "Single connection to profile not requiring redundancy passes validation")
+	assert.Nil(t, unknownErr, "Unavailable profile is not validated")
+	assert.Nil(t, withoutProfileErr, "Connection without profile is not validated")
+}
+
+func TestFabricL2Connection_validateSellerRegion(t *testing.T) {
+	//given
+	profileUUID := randString(36)
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		if uuid != profileUUID {
+			return nil, fmt.Errorf("profile not found")
+		}
+		return &ecx.L2ServiceProfile{
+			UUID: ecx.String(uuid),
+			Metros: []ecx.L2SellerProfileMetro{
+				{Code: ecx.String("SV"), Regions: map[string]string{"us-west-1": "N. California"}},
+				{Code: ecx.String("FR"), Regions: map[string]string{"eu-central-1": "Frankfurt"}},
+				{Code: ecx.String("AM")},
+			},
+		}, nil
+	}
+	//when
+	matchingErr := validateECXL2ConnectionSellerRegion(fetchFunc, profileUUID, "SV", "us-west-1")
+	mismatchingErr := validateECXL2ConnectionSellerRegion(fetchFunc, profileUUID, "SV", "eu-central-1")
+	noRegionsErr := validateECXL2ConnectionSellerRegion(fetchFunc, profileUUID, "AM", "eu-west-1")
+	unknownMetroErr := validateECXL2ConnectionSellerRegion(fetchFunc, profileUUID, "DC", "us-east-1")
+	unknownProfileErr := validateECXL2ConnectionSellerRegion(fetchFunc, randString(36), "SV", "eu-central-1")
+	withoutRegionErr := validateECXL2ConnectionSellerRegion(fetchFunc, profileUUID, "SV", "")
+	//then
+	assert.Nil(t, matchingErr, "Region available in metro does not return error")
+	assert.NotNil(t, mismatchingErr, "Region not available in metro returns error")
+	assert.Contains(t, mismatchingErr.Error(), "us-west-1", "Error lists available regions")
+	assert.Nil(t, noRegionsErr, "Metro without region details is not validated")
+	assert.Nil(t, unknownMetroErr, "Metro not present in profile is not validated")
+	assert.Nil(t, unknownProfileErr, "Unavailable profile is not validated")
+	assert.Nil(t, withoutRegionErr, "Connection without region is not validated")
+}
+
+func TestFabricL2Connection_validateAuthorizationKey(t *testing.T) {
+	//given
+	profiles := map[string]*ecx.L2ServiceProfile{
+		"aws":     {Name: ecx.String("AWS Direct Connect"), IntegrationID: ecx.String("AWS-Direct-Connect-1")},
+		"azure":   {Name: ecx.String("Azure ExpressRoute"), IntegrationID: ecx.String("Azure-ExpressRoute-1")},
+		"gcp":     {Name: ecx.String("Google Cloud Partner Interconnect Zone 1")},
+		"unknown": {Name: ecx.String("Some Seller Service")},
+	}
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		if profile, ok := profiles[uuid]; ok {
+			return profile, nil
+		}
+		return nil, fmt.Errorf("profile not found")
+	}
+	//when
+	awsValidErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, "aws", "123456789012")
+	awsInvalidErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, "aws", "12345")
+	azureValidErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, "azure", "9A3F1E4B-2C5D-4E6F-8A7B-0C1D2E3F4A5B")
+	azureInvalidErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, "azure", "123456789012")
+	gcpValidErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, "gcp", "7e51371e-72a3-40b5-b844-2e3efefaee59/us-central1/2")
+	gcpInvalidErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, "gcp", "7e51371e-72a3-40b5-b844-2e3efefaee59")
+	unknownProviderErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, "unknown", randString(10))
+	unknownProfileErr := validateECXL2ConnectionAuthorizationKey(fetchFunc, randString(36), randString(10))
+	//then
+	assert.Nil(t, awsValidErr, "AWS account ID is valid")
+	assert.NotNil(t, awsInvalidErr, "Malformed AWS account ID is invalid")
+	assert.Nil(t, azureValidErr, "Azure service key is valid")
+	assert.NotNil(t, azureInvalidErr, "Malformed Azure service key is invalid")
+	assert.Nil(t, gcpValidErr, "GCP pairing key is valid")
+	assert.NotNil(t, gcpInvalidErr, "Malformed GCP pairing key is invalid")
+	assert.Nil(t, unknownProviderErr, "Key for unknown provider is not validated")
+	assert.Nil(t, unknownProfileErr, "Key for unavailable profile is not validated")
+}
+
+func TestFabricL2Connection_getSellerOrganizationName(t *testing.T) {
+	//given
+	profiles := map[string]*ecx.L2ServiceProfile{
+		"org":    {OrganizationName: ecx.String("Seller Org"), GlobalOrganization: ecx.String("Seller Global Org")},
+		"global": {GlobalOrganization: ecx.String("Seller Global Org")},
+	}
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		if profile, ok := profiles[uuid]; ok {
+			return profile, nil
+		}
+		return nil, fmt.Errorf("profile not found")
+	}
+	//when
+	orgName, orgErr := getECXL2ConnectionSellerOrganizationName(fetchFunc, &ecx.L2Connection{ProfileUUID: ecx.String("org")})
+	globalName, globalErr := getECXL2ConnectionSellerOrganizationName(fetchFunc, &ecx.L2Connection{ProfileUUID: ecx.String("global")})
+	portName, portErr := getECXL2ConnectionSellerOrganizationName(fetchFunc, &ecx.L2Connection{ZSidePortUUID: ecx.String(randString(36))})
+	_, missingErr := getECXL2ConnectionSellerOrganizationName(fetchFunc, &ecx.L2Connection{ProfileUUID: ecx.String(randString(36))})
+	//then
+	assert.Nil(t, orgErr, "Organization name resolution does not return error")
+	assert.Equal(t, "Seller Org", orgName, "Organization name matches")
+	assert.Nil(t, globalErr, "Global organization name resolution does not return error")
+	assert.Equal(t, "Seller Global Org", globalName, "Global organization name is used when organization name is missing")
+	assert.Nil(t, portErr, "Port to port connection does not return error")
+	assert.Empty(t, portName, "Port to port connection has no seller organization")
+	assert.NotNil(t, missingErr, "Unavailable profile returns error")
+}
+
+func TestFabricL2Connection_validateSpeedBand(t *testing.T) {
+	//given
+	profiles := map[string]*ecx.L2ServiceProfile{
+		"bands": {Name: ecx.String("Seller Service"), SpeedBands: []ecx.L2ServiceProfileSpeedBand{
+			{Speed: ecx.Int(50), SpeedUnit: ecx.String("MB")},
+			{Speed: ecx.Int(1), SpeedUnit: ecx.String("GB")},
+		}},
+		"custom": {Name: ecx.String("Custom Speed Service"), AllowCustomSpeed: ecx.Bool(true), SpeedBands: []ecx.L2ServiceProfileSpeedBand{
+			{Speed: ecx.Int(50), SpeedUnit: ecx.String("MB")},
+		}},
+	}
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		if profile, ok := profiles[uuid]; ok {
+			return profile, nil
+		}
+		return nil, fmt.Errorf("profile not found")
+	}
+	supported := &ecx.L2Connection{ProfileUUID: ecx.String("bands"), Speed: ecx.Int(1), SpeedUnit: ecx.String("GB")}
+	unsupported := &ecx.L2Connection{ProfileUUID: ecx.String("bands"), Speed: ecx.Int(10), SpeedUnit: ecx.String("GB")}
+	custom := &ecx.L2Connection{ProfileUUID: ecx.String("custom"), Speed: ecx.Int(10), SpeedUnit: ecx.String("GB")}
+	portToPort := &ecx.L2Connection{ZSidePortUUID: ecx.String(randString(36)), Speed: ecx.Int(10), SpeedUnit: ecx.String("GB")}
+	//when
+	supportedErr := validateECXL2ConnectionSpeedBand(fetchFunc, supported, nil)
+	unsupportedErr := validateECXL2ConnectionSpeedBand(fetchFunc, unsupported)
+	secondaryErr := validateECXL2ConnectionSpeedBand(fetchFunc, supported, unsupported)
+	customErr := validateECXL2ConnectionSpeedBand(fetchFunc, custom)
+	portToPortErr := validateECXL2ConnectionSpeedBand(fetchFunc, portToPort)
+	unknownProfileErr := validateECXL2ConnectionSpeedBand(fetchFunc, &ecx.L2Connection{ProfileUUID: ecx.String(randString(36)), Speed: ecx.Int(1), SpeedUnit: ecx.String("GB")})
+	//then
+	assert.Nil(t, supportedErr, "Speed offered by service profile passes validation")
+	assert.NotNil(t, unsupportedErr, "Speed not offered by service profile fails validation")
+	assert.Contains(t, unsupportedErr.Error(), "50 MB, 1 GB", "Validation error lists supported speeds")
+	assert.NotNil(t, secondaryErr, "Secondary connection speed not offered by service profile fails validation")
+	assert.Nil(t, customErr, "Profile that allows custom speed passes validation")
+	assert.Nil(t, portToPortErr, "Connection without service profile passes validation")
+	assert.NotNil(t, unknownProfileErr, "Unavailable profile returns error")
+}
+
+func TestFabricL2Connection_validateDisruptiveSpeedChange(t *testing.T) {
+	//given
+	profiles := map[string]*ecx.L2ServiceProfile{
+		"api":    {Name: ecx.String("AWS Direct Connect"), SpeedFromAPI: ecx.Bool(true)},
+		"manual": {Name: ecx.String("Seller Service"), SpeedFromAPI: ecx.Bool(false)},
+	}
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		if profile, ok := profiles[uuid]; ok {
+			return profile, nil
+		}
+		return nil, fmt.Errorf("profile not found")
+	}
+	//when
+	unacknowledgedErr := validateECXL2ConnectionDisruptiveSpeedChange(fetchFunc, "api", false)
+	acknowledgedErr := validateECXL2ConnectionDisruptiveSpeedChange(fetchFunc, "api", true)
+	nonDisruptiveErr := validateECXL2ConnectionDisruptiveSpeedChange(fetchFunc, "manual", false)
+	withoutProfileErr := validateECXL2ConnectionDisruptiveSpeedChange(fetchFunc, "", false)
+	unknownProfileErr := validateECXL2ConnectionDisruptiveSpeedChange(fetchFunc, randString(36), false)
+	//then
+	assert.NotNil(t, unacknowledgedErr, "Unacknowledged disruptive speed change is blocked")
+	assert.Nil(t, acknowledgedErr, "Acknowledged disruptive speed change is allowed")
+	assert.Nil(t, nonDisruptiveErr, "Non disruptive speed change is allowed")
+	assert.Nil(t, withoutProfileErr, "Speed change of connection without profile is allowed")
+	assert.Nil(t, unknownProfileErr, "Speed change is allowed when profile is not available")
+}
+
+func TestFabricL2Connection_getCreateStatuses(t *testing.T) {
+	//given
+	autoApproval := &ecx.L2ServiceProfile{APIAvailable: ecx.Bool(true)}
+	sellerApproval := &ecx.L2ServiceProfile{APIAvailable: ecx.Bool(false)}
+	//when
+	autoPending, autoTarget := getECXL2ConnectionCreateStatuses(autoApproval)
+	sellerPending, sellerTarget := getECXL2ConnectionCreateStatuses(sellerApproval)
+	_, noProfileTarget := getECXL2ConnectionCreateStatuses(nil)
+	//then
+	assert.Contains(t, autoPending, ecx.ConnectionStatusPendingApproval, "Awaiting approval is pending status for auto approval profile")
+	assert.NotContains(t, autoTarget, ecx.ConnectionStatusPendingApproval, "Awaiting approval is not target status for auto approval profile")
+	assert.NotContains(t, sellerPending, ecx.ConnectionStatusPendingApproval, "Awaiting approval is not pending status for seller approval profile")
+	assert.Contains(t, sellerTarget, ecx.ConnectionStatusPendingApproval, "Awaiting approval is target status for seller approval profile")
+	assert.Contains(t, noProfileTarget, ecx.ConnectionStatusPendingApproval, "Awaiting approval is target status without profile")
+}
+
+func TestFabricL2Connection_overrideCreateTargetStatuses(t *testing.T) {
+	//given
+	pending, target := getECXL2ConnectionCreateStatuses(nil)
+	override := []string{ecx.ConnectionStatusProvisioned, ecx.ConnectionStatusAvailable}
+	//when
+	newPending, newTarget := overrideECXL2ConnectionCreateTargetStatuses(pending, target, override)
+	//then
+	assert.Equal(t, override, newTarget, "Target statuses are replaced with override")
+	assert.Contains(t, newPending, ecx.ConnectionStatusProvisioning, "Default pending status remains pending")
+	assert.Contains(t, newPending, ecx.ConnectionStatusPendingApproval, "Default target status that is not overridden becomes pending")
+	assert.NotContains(t, newPending, ecx.ConnectionStatusProvisioned, "Overridden target status is not pending")
+}
+
+func TestFabricL2Connection_providerStatusWaitConfiguration(t *testing.T) {
+	//given
+	connID := randString(36)
+	var queriedConnID string
+	statuses := []string{ecx.ConnectionStatusPendingApproval, ecx.ConnectionStatusProvisioning, ecx.ConnectionStatusProvisioned}
+	fetchFunc := func(uuid string) (*ecx.L2Connection, error) {
+		queriedConnID = uuid
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		return &ecx.L2Connection{Status: ecx.String(ecx.ConnectionStatusPendingApproval), ProviderStatus: ecx.String(status)}, nil
+	}
+	pollInterval := 10 * time.Millisecond
+	timeout := 10 * time.Minute
+	//when
+	waitConfig := createECXL2ConnectionProviderStatusWaitConfiguration(refreshECXL2ConnectionProviderStatus(fetchFunc, connID), pollInterval, timeout)
+	waitConfig.Delay = pollInterval
+	result, err := waitConfig.WaitForStateContext(context.Background())
+	//then
+	assert.Nil(t, err, "WaitForState does not return an error")
+	assert.Equal(t, connID, queriedConnID, "Queried connection ID matches")
+	assert.Equal(t, ecx.ConnectionStatusProvisioned, ecx.StringValue(result.(*ecx.L2Connection).ProviderStatus), "Wait completes once connection is provisioned on provider side")
+	assert.Equal(t, timeout, waitConfig.Timeout, "Provider status wait configuration timeout matches")
+	assert.Equal(t, pollInterval, waitConfig.MinTimeout, "Provider status wait configuration min timeout matches")
+}
+
+func TestFabricL2Connection_getStatusChangedAt(t *testing.T) {
+	//given
+	prevChangedAt := "2021-05-01T10:00:00Z"
+	now := time.Date(2021, 5, 2, 12, 30, 0, 0, time.UTC)
+	//when
+	unchanged := getECXL2ConnectionStatusChangedAt(ecx.ConnectionStatusProvisioning, prevChangedAt, ecx.ConnectionStatusProvisioning, now)
+	changed := getECXL2ConnectionStatusChangedAt(ecx.ConnectionStatusProvisioning, prevChangedAt, ecx.ConnectionStatusProvisioned, now)
+	missing := getECXL2ConnectionStatusChangedAt(ecx.ConnectionStatusProvisioned, "", ecx.ConnectionStatusProvisioned, now)
+	//then
+	assert.Equal(t, prevChangedAt, unchanged, "Time is kept when status does not change")
+	assert.Equal(t, "2021-05-02T12:30:00Z", changed, "Time is updated when status changes")
+	assert.Equal(t, "2021-05-02T12:30:00Z", missing, "Time is set when it was not observed before")
+}
+
+func TestFabricL2Connection_appendProviderStatusHistory(t *testing.T) {
+	//given
+	now := time.Now()
+	entry := func(status string, at time.Time) map[string]interface{} {
+		return map[string]interface{}{
+			ecxL2ConnectionProviderStatusHistorySchemaNames["ProviderStatus"]: status,
+			ecxL2ConnectionProviderStatusHistorySchemaNames["ObservedAt"]:     at.UTC().Format(time.RFC3339),
+		}
+	}
+	history := []interface{}{entry(ecx.ConnectionStatusPendingApproval, now.Add(-time.Hour))}
+	//when
+	unchanged := appendECXL2ConnectionProviderStatusHistory(history, ecx.ConnectionStatusPendingApproval, now, 3)
+	changed := appendECXL2ConnectionProviderStatusHistory(history, ecx.ConnectionStatusProvisioned, now, 3)
+	empty := appendECXL2ConnectionProviderStatusHistory(history, "", now, 3)
+	capped := history
+	for _, status := range []string{"A", "B", "C", "D"} {
+		capped = appendECXL2ConnectionProviderStatusHistory(capped, status, now, 3)
+	}
+	//then
+	assert.Equal(t, history, unchanged, "Unchanged status is not added")
+	assert.Equal(t, []interface{}{history[0], entry(ecx.ConnectionStatusProvisioned, now)}, changed, "Changed status is added")
+	assert.Equal(t, history, empty, "Empty status is not added")
+	assert.Equal(t, []interface{}{entry("B", now), entry("C", now), entry("D", now)}, capped, "Only most recent statuses are kept")
+}
+
+func TestFabricL2Connection_isPrimary(t *testing.T) {
+	//given
+	primary := &ecx.L2Connection{RedundancyType: ecx.String("PRIMARY")}
+	secondary := &ecx.L2Connection{RedundancyType: ecx.String("SECONDARY")}
+	unknown := &ecx.L2Connection{}
+	//when
+	primaryResult := isECXL2ConnectionPrimary(primary)
+	secondaryResult := isECXL2ConnectionPrimary(secondary)
+	unknownResult := isECXL2ConnectionPrimary(unknown)
+	//then
+	assert.True(t, primaryResult, "Primary connection is primary")
+	assert.False(t, secondaryResult, "Secondary connection is not primary")
+	assert.False(t, unknownResult, "Connection without redundancy type is not primary")
+}
+
+func TestFabricL2Connection_getFingerprint(t *testing.T) {
+	//given
+	conn := &ecx.L2Connection{
+		Name:            ecx.String(randString(10)),
+		ProfileUUID:     ecx.String(randString(36)),
+		PortUUID:        ecx.String(randString(36)),
+		VlanSTag:        ecx.Int(100),
+		SellerMetroCode: ecx.String("SV"),
+	}
+	renamed := *conn
+	renamed.Name = ecx.String(randString(10))
+	retagged := *conn
+	retagged.VlanSTag = ecx.Int(101)
+	//when
+	fingerprint := getECXL2ConnectionFingerprint(conn)
+	renamedFingerprint := getECXL2ConnectionFingerprint(&renamed)
+	retaggedFingerprint := getECXL2ConnectionFingerprint(&retagged)
+	//then
+	assert.NotEmpty(t, fingerprint, "Fingerprint is not empty")
+	assert.Equal(t, fingerprint, renamedFingerprint, "Fingerprint does not depend on updatable parameters")
+	assert.NotEqual(t, fingerprint, retaggedFingerprint, "Fingerprint depends on immutable parameters")
+}
+
+func TestFabricL2Connection_validateCTagEncapsulation(t *testing.T) {
+	//given
+	qinqUUID := randString(36)
+	dot1qUUID := randString(36)
+	fetchFunc := func() ([]ecx.Port, error) {
+		return []ecx.Port{
+			{UUID: ecx.String(qinqUUID), Encapsulation: ecx.String("QINQ")},
+			{UUID: ecx.String(dot1qUUID), Encapsulation: ecx.String("Dot1q")},
+		}, nil
+	}
+	qinqTagged := &ecx.L2Connection{PortUUID: ecx.String(qinqUUID), VlanSTag: ecx.Int(100), VlanCTag: ecx.Int(200)}
+	dot1qTagged := &ecx.L2Connection{PortUUID: ecx.String(dot1qUUID), VlanSTag: ecx.Int(100), VlanCTag: ecx.Int(200)}
+	dot1qUntagged := &ecx.L2Connection{PortUUID: ecx.String(dot1qUUID), VlanSTag: ecx.Int(100)}
+	//when
+	qinqTaggedErr := validateECXL2ConnectionCTagEncapsulation(fetchFunc, qinqTagged)
+	dot1qTaggedErr := validateECXL2ConnectionCTagEncapsulation(fetchFunc, dot1qTagged)
+	dot1qUntaggedErr := validateECXL2ConnectionCTagEncapsulation(fetchFunc, dot1qUntagged)
+	//then
+	assert.Nil(t, qinqTaggedErr, "C-Tag on QinQ port passes validation")
+	assert.NotNil(t, dot1qTaggedErr, "C-Tag on Dot1q port fails validation")
+	assert.Nil(t, dot1qUntaggedErr, "Untagged connection on Dot1q port passes validation")
+}
+
+func TestFabricL2Connection_validateVlanTags(t *testing.T) {
+	//given
+	portUUID := randString(36)
+	fetchFunc := func(statuses []string) ([]ecx.L2Connection, error) {
+		return []ecx.L2Connection{
+			{Name: ecx.String("existing"), PortUUID: ecx.String(portUUID), VlanSTag: ecx.Int(100), VlanCTag: ecx.Int(200)},
+		}, nil
+	}
+	sameSTag := &ecx.L2Connection{PortUUID: ecx.String(portUUID), VlanSTag: ecx.Int(100), VlanCTag: ecx.Int(201)}
+	conflicting := &ecx.L2Connection{PortUUID: ecx.String(portUUID), VlanSTag: ecx.Int(100), VlanCTag: ecx.Int(200)}
+	otherPort := &ecx.L2Connection{PortUUID: ecx.String(randString(36)), VlanSTag: ecx.Int(100), VlanCTag: ecx.Int(200)}
+	secondary := &ecx.L2Connection{PortUUID: ecx.String(portUUID), VlanSTag: ecx.Int(100), VlanCTag: ecx.Int(201)}
+	//when
+	sameSTagErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopePort, sameSTag)
+	conflictingErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopePort, conflicting)
+	otherPortErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopePort, otherPort)
+	pairErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopePort, sameSTag, secondary)
+	//then
+	assert.Nil(t, sameSTagErr, "Connection sharing S-Tag with different C-Tag passes validation")
+	assert.NotNil(t, conflictingErr, "Connection with same S-Tag and C-Tag fails validation")
+	assert.Nil(t, otherPortErr, "Connection on other port passes validation")
+	assert.NotNil(t, pairErr, "Primary and secondary connection with same tags on same port fail validation")
+}
+
+func TestFabricL2Connection_validateVlanTagsScope(t *testing.T) {
+	//given
+	profileUUID := randString(36)
+	fetchFunc := func(statuses []string) ([]ecx.L2Connection, error) {
+		return []ecx.L2Connection{
+			{Name: ecx.String("existing"), PortUUID: ecx.String(randString(36)), ProfileUUID: ecx.String(profileUUID),
+				SellerMetroCode: ecx.String("SV"), VlanSTag: ecx.Int(100)},
+		}, nil
+	}
+	sameMetro := &ecx.L2Connection{PortUUID: ecx.String(randString(36)), ProfileUUID: ecx.String(profileUUID),
+		SellerMetroCode: ecx.String("SV"), VlanSTag: ecx.Int(100)}
+	otherMetro := &ecx.L2Connection{PortUUID: ecx.String(randString(36)), ProfileUUID: ecx.String(profileUUID),
+		SellerMetroCode: ecx.String("DC"), VlanSTag: ecx.Int(100)}
+	otherProfile := &ecx.L2Connection{PortUUID: ecx.String(randString(36)), ProfileUUID: ecx.String(randString(36)),
+		SellerMetroCode: ecx.String("SV"), VlanSTag: ecx.Int(100)}
+	//when
+	portScopeErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopePort, sameMetro)
+	metroScopeErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopeMetro, sameMetro)
+	otherMetroErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopeMetro, otherMetro)
+	accountScopeErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopeAccount, otherMetro)
+	otherProfileErr := validateECXL2ConnectionVlanTags(fetchFunc, ecxL2ConnectionVlanScopeAccount, otherProfile)
+	//then
+	assert.Nil(t, portScopeErr, "Connection on other port passes port scope validation")
+	assert.NotNil(t, metroScopeErr, "Connection with same tags in same metro fails metro scope validation")
+	assert.Nil(t, otherMetroErr, "Connection with same tags in other metro passes metro scope validation")
+	assert.NotNil(t, accountScopeErr, "Connection with same tags in other metro fails account scope validation")
+	assert.Nil(t, otherProfileErr, "Connection to other service profile passes account scope validation")
+}
+
+func TestFabricL2Connection_validateUniqueName(t *testing.T) {
+	//given
+	fetchFunc := func(statuses []string) ([]ecx.L2Connection, error) {
+		return []ecx.L2Connection{{UUID: ecx.String(randString(36)), Name: ecx.String("existing")}}, nil
+	}
+	unique := &ecx.L2Connection{Name: ecx.String("unique")}
+	duplicated := &ecx.L2Connection{Name: ecx.String("existing")}
+	secondary := &ecx.L2Connection{Name: ecx.String("unique")}
+	//when
+	uniqueErr := validateECXL2ConnectionUniqueName(fetchFunc, unique, nil)
+	duplicatedErr := validateECXL2ConnectionUniqueName(fetchFunc, duplicated, nil)
+	pairErr := validateECXL2ConnectionUniqueName(fetchFunc, unique, secondary)
+	//then
+	assert.Nil(t, uniqueErr, "Connection with unique name passes validation")
+	assert.NotNil(t, duplicatedErr, "Connection with name of existing connection fails validation")
+	assert.NotNil(t, pairErr, "Primary and secondary connection with same name fail validation")
+}
+
+func TestFabricL2Connection_validateASide(t *testing.T) {
+	//given
+	portUUID := randString(36)
+	deviceUUID := randString(36)
+	fetchPorts := func() ([]ecx.Port, error) {
+		return []ecx.Port{{UUID: ecx.String(portUUID)}}, nil
+	}
+	fetchDevice := func(uuid string) (*ne.Device, error) {
+		if uuid != deviceUUID {
+			return nil, rest.Error{HTTPCode: 404, Message: "device not found"}
+		}
+		return &ne.Device{UUID: ne.String(uuid)}, nil
+	}
+	ownedPort := &ecx.L2Connection{PortUUID: ecx.String(portUUID)}
+	foreignPort := &ecx.L2Connection{PortUUID: ecx.String(randString(36))}
+	ownedDevice := &ecx.L2Connection{DeviceUUID: ecx.String(deviceUUID)}
+	foreignDevice := &ecx.L2Connection{DeviceUUID: ecx.String(randString(36))}
+	//when
+	ownedErr := validateECXL2ConnectionASide(fetchPorts, fetchDevice, ownedPort, ownedDevice)
+	foreignPortErr := validateECXL2ConnectionASide(fetchPorts, fetchDevice, ownedPort, foreignPort)
+	foreignDeviceErr := validateECXL2ConnectionASide(fetchPorts, fetchDevice, foreignDevice)
+	//then
+	assert.Nil(t, ownedErr, "Port and device owned by account pass validation")
+	assert.NotNil(t, foreignPortErr, "Port not owned by account fails validation")
+	assert.NotNil(t, foreignDeviceErr, "Device not owned by account fails validation")
+	assert.Contains(t, foreignDeviceErr.Error(), "not available for this account", "Device validation error is descriptive")
+}
+
+func TestFabricL2Connection_getASideMetroCode(t *testing.T) {
+	//given
+	portUUID := randString(36)
+	deviceUUID := randString(36)
+	fetchPorts := func() ([]ecx.Port, error) {
+		return []ecx.Port{{UUID: ecx.String(portUUID), MetroCode: ecx.String("SV")}}, nil
+	}
+	fetchDevice := func(uuid string) (*ne.Device, error) {
+		return &ne.Device{UUID: ne.String(uuid), MetroCode: ne.String("DC")}, nil
+	}
+	fromPort := &ecx.L2Connection{PortUUID: ecx.String(portUUID)}
+	fromDevice := &ecx.L2Connection{DeviceUUID: ecx.String(deviceUUID)}
+	fromUnknownPort := &ecx.L2Connection{PortUUID: ecx.String(randString(36))}
+	//when
+	portMetro, portErr := getECXL2ConnectionASideMetroCode(fetchPorts, fetchDevice, fromPort)
+	deviceMetro, deviceErr := getECXL2ConnectionASideMetroCode(fetchPorts, fetchDevice, fromDevice)
+	unknownMetro, unknownErr := getECXL2ConnectionASideMetroCode(fetchPorts, fetchDevice, fromUnknownPort)
+	//then
+	assert.Nil(t, portErr, "Port metro resolution does not return error")
+	assert.Equal(t, "SV", portMetro, "Metro of a port is resolved")
+	assert.Nil(t, deviceErr, "Device metro resolution does not return error")
+	assert.Equal(t, "DC", deviceMetro, "Metro of a device is resolved")
+	assert.Nil(t, unknownErr, "Unknown port metro resolution does not return error")
+	assert.Empty(t, unknownMetro, "Metro of unknown port is empty")
+}
+
+func TestFabricL2Connection_validateTopology(t *testing.T) {
+	//given
+	portUUID := randString(36)
+	localProfileUUID := randString(36)
+	remoteProfileUUID := randString(36)
+	fetchPorts := func() ([]ecx.Port, error) {
+		return []ecx.Port{{UUID: ecx.String(portUUID), MetroCode: ecx.String("SV")}}, nil
+	}
+	fetchDevice := func(uuid string) (*ne.Device, error) {
+		return &ne.Device{UUID: ne.String(uuid), MetroCode: ne.String("DC")}, nil
+	}
+	fetchProfile := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		return &ecx.L2ServiceProfile{
+			UUID:     ecx.String(uuid),
+			Metros:   []ecx.L2SellerProfileMetro{{Code: ecx.String("SV")}, {Code: ecx.String("DC")}},
+			Features: ecx.L2ServiceProfileFeatures{CloudReach: ecx.Bool(uuid == remoteProfileUUID)},
+		}, nil
+	}
+	local := &ecx.L2Connection{PortUUID: ecx.String(portUUID), ProfileUUID: ecx.String(localProfileUUID), SellerMetroCode: ecx.String("SV")}
+	remote := &ecx.L2Connection{PortUUID: ecx.String(portUUID), ProfileUUID: ecx.String(remoteProfileUUID), SellerMetroCode: ecx.String("DC")}
+	unreachable := &ecx.L2Connection{PortUUID: ecx.String(portUUID), ProfileUUID: ecx.String(localProfileUUID), SellerMetroCode: ecx.String("DC")}
+	absent := &ecx.L2Connection{PortUUID: ecx.String(portUUID), ProfileUUID: ecx.String(remoteProfileUUID), SellerMetroCode: ecx.String("AM")}
+	secondaryFromDevice := &ecx.L2Connection{DeviceUUID: ecx.String(randString(36))}
+	//when
+	localErr := validateECXL2ConnectionTopology(fetchPorts, fetchDevice, fetchProfile, local, nil)
+	remoteErr := validateECXL2ConnectionTopology(fetchPorts, fetchDevice, fetchProfile, remote, nil)
+	unreachableErr := validateECXL2ConnectionTopology(fetchPorts, fetchDevice, fetchProfile, unreachable, nil)
+	absentErr := validateECXL2ConnectionTopology(fetchPorts, fetchDevice, fetchProfile, absent, nil)
+	unreachableSecondaryErr := validateECXL2ConnectionTopology(fetchPorts, fetchDevice, fetchProfile, local, secondaryFromDevice)
+	//then
+	assert.Nil(t, localErr, "Local connection is feasible")
+	assert.Nil(t, remoteErr, "Remote connection to profile with remote reach is feasible")
+	assert.NotNil(t, unreachableErr, "Remote connection to profile without remote reach is infeasible")
+	assert.NotNil(t, absentErr, "Connection to metro where profile is not present is infeasible")
+	assert.NotNil(t, unreachableSecondaryErr, "Secondary connection uses primary profile and seller metro")
+}
+
+func TestFabricL2Connection_isRemote(t *testing.T) {
+	//given
+	conn := &ecx.L2Connection{SellerMetroCode: ecx.String("SV")}
+	//when
+	local := isECXL2ConnectionRemote("sv", conn)
+	remote := isECXL2ConnectionRemote("DC", conn)
+	unknown := isECXL2ConnectionRemote("", conn)
+	//then
+	assert.False(t, local, "Connection within same metro is not remote")
+	assert.True(t, remote, "Connection across metros is remote")
+	assert.False(t, unknown, "Connection with unknown a-side metro is not remote")
+}
+
+func TestFabricL2Connection_isAuthorizationKeyInvalid(t *testing.T) {
+	//given
+	invalid := &ecx.L2Connection{
+		Actions: []ecx.L2ConnectionAction{
+			{
+				RequiredData: []ecx.L2ConnectionActionData{
+					{Key: ecx.String("authorizationKey"), IsEditable: ecx.Bool(true)},
+				},
+			},
+		},
+	}
+	valid := &ecx.L2Connection{
+		Actions: []ecx.L2ConnectionAction{
+			{
+				RequiredData: []ecx.L2ConnectionActionData{
+					{Key: ecx.String("awsConnectionId"), IsEditable: ecx.Bool(false)},
+				},
+			},
+		},
+	}
+	//when
+	invalidResult := isECXL2ConnectionAuthorizationKeyInvalid(invalid)
+	validResult := isECXL2ConnectionAuthorizationKeyInvalid(valid)
+	//then
+	assert.True(t, invalidResult, "Connection requiring new authorization key is detected")
+	assert.False(t, validResult, "Connection without authorization key action is not detected")
+}
+
+func TestFabricL2Connection_getLifecycleStage(t *testing.T) {
+	//given
+	input := []struct {
+		status         string
+		providerStatus string
+		expected       string
+	}{
+		{ecx.ConnectionStatusProvisioning, ecx.ConnectionStatusNotAvailable, ecxL2ConnectionLifecycleStageProvisioning},
+		{ecx.ConnectionStatusPendingApproval, ecx.ConnectionStatusNotAvailable, ecxL2ConnectionLifecycleStageNeedsAction},
+		{ecx.ConnectionStatusProvisioned, ecx.ConnectionStatusPendingApproval, ecxL2ConnectionLifecycleStageNeedsAction},
+		{ecx.ConnectionStatusProvisioned, ecx.ConnectionStatusAvailable, ecxL2ConnectionLifecycleStageActive},
+		{ecx.ConnectionStatusDeprovisioning, ecx.ConnectionStatusAvailable, ecxL2ConnectionLifecycleStageDeprovisioning},
+		{ecx.ConnectionStatusDeprovisioned, ecx.ConnectionStatusDeprovisioned, ecxL2ConnectionLifecycleStageDeprovisioned},
+		{"", "", ecxL2ConnectionLifecycleStageUnknown},
+	}
+	for _, in := range input {
+		//when
+		out := getECXL2ConnectionLifecycleStage(in.status, in.providerStatus)
+		//then
+		assert.Equal(t, in.expected, out, "Lifecycle stage for status %q and provider status %q matches", in.status, in.providerStatus)
+	}
+}
+
+func TestFabricL2Connection_suppressDeviceInterfaceIDDiff(t *testing.T) {
+	//given
+	key := ecxL2ConnectionSchemaNames["DeviceInterfaceID"]
+	existing := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), make(map[string]interface{}))
+	existing.SetId(randString(36))
+	created := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), make(map[string]interface{}))
+	//when
+	addedResult := suppressECXL2ConnectionDeviceInterfaceIDDiff(key, "0", "5", existing)
+	firstAvailableResult := suppressECXL2ConnectionDeviceInterfaceIDDiff(key, "5", "", existing)
+	changedResult := suppressECXL2ConnectionDeviceInterfaceIDDiff(key, "5", "6", existing)
+	createdResult := suppressECXL2ConnectionDeviceInterfaceIDDiff(key, "", "5", created)
+	//then
+	assert.False(t, addedResult, "Diff is not suppressed when interface is set for existing connection")
+	assert.True(t, firstAvailableResult, "Diff is suppressed when first available interface is requested")
+	assert.False(t, changedResult, "Diff is not suppressed when interface is changed")
+	assert.False(t, createdResult, "Diff is not suppressed for new connection")
+}
+
+func TestFabricL2Connection_isSpeedIncreasedBySeller(t *testing.T) {
+	//given
+	type bandwidth struct {
+		speed     int
+		speedUnit string
+	}
+	current := []bandwidth{{100, "MB"}, {1, "GB"}, {50, "MB"}, {1, "GB"}, {100, "MB"}}
+	config := []bandwidth{{50, "MB"}, {500, "MB"}, {100, "MB"}, {1, "GB"}, {0, ""}}
+	expected := []bool{true, true, false, false, false}
+	//when
+	result := make([]bool, len(current))
+	for i := range current {
+		result[i] = isECXL2ConnectionSpeedIncreasedBySeller(current[i].speed, current[i].speedUnit, config[i].speed, config[i].speedUnit)
+	}
+	//then
+	assert.Equal(t, expected, result, "Seller speed increase results match")
+}
+
+func TestFabricL2Connection_retryCreate(t *testing.T) {
+	//given
+	transientErr := rest.Error{HTTPCode: http.StatusServiceUnavailable}
+	permanentErr := rest.Error{HTTPCode: http.StatusBadRequest, ApplicationErrors: []rest.ApplicationError{{Code: "IC-LAYER2-4000"}}}
+	connID := randString(36)
+	transientCalls := 0
+	transientCreate := func() (*string, error) {
+		transientCalls++
+		if transientCalls < 2 {
+			return nil, transientErr
+		}
+		return ecx.String(connID), nil
+	}
+	permanentCalls := 0
+	permanentCreate := func() (*string, error) {
+		permanentCalls++
+		return nil, permanentErr
+	}
+	exhaustedCalls := 0
+	exhaustedCreate := func() (*string, error) {
+		exhaustedCalls++
+		return nil, transientErr
+	}
+	//when
+	transientID, transientResultErr := retryECXL2ConnectionCreate(context.Background(), transientCreate, 3, time.Millisecond)
+	_, permanentResultErr := retryECXL2ConnectionCreate(context.Background(), permanentCreate, 3, time.Millisecond)
+	_, exhaustedResultErr := retryECXL2ConnectionCreate(context.Background(), exhaustedCreate, 3, time.Millisecond)
+	//then
+	assert.Nil(t, transientResultErr, "Create succeeds after transient error")
+	assert.Equal(t, connID, ecx.StringValue(transientID), "Connection identifier matches")
+	assert.Equal(t, 2, transientCalls, "Create was retried after transient error")
+	assert.Equal(t, permanentErr, permanentResultErr, "Permanent error is returned")
+	assert.Equal(t, 1, permanentCalls, "Create was not retried after permanent error")
+	assert.Equal(t, transientErr, exhaustedResultErr, "Transient error is returned when attempts are exhausted")
+	assert.Equal(t, 3, exhaustedCalls, "Create was attempted given number of times")
+}
+
+func TestFabricL2Connection_drain(t *testing.T) {
+	//given
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	//when
+	noDrainErr := drainECXL2Connection(cancelledCtx, randString(36), 0)
+	drainErr := drainECXL2Connection(context.Background(), randString(36), time.Millisecond)
+	cancelledErr := drainECXL2Connection(cancelledCtx, randString(36), time.Hour)
+	//then
+	assert.Nil(t, noDrainErr, "Zero drain period does not wait")
+	assert.Nil(t, drainErr, "Drain completes after given period")
+	assert.Equal(t, context.Canceled, cancelledErr, "Drain is interrupted when context is cancelled")
+}
+
+func TestFabricL2Connection_updateResourceData_importedDeviceInterface(t *testing.T) {
+	//given
+	d := schema.TestResourceDataRaw(t, createECXL2ConnectionResourceSchema(), make(map[string]interface{}))
+	d.SetId(randString(36))
+	primary := &ecx.L2Connection{
+		UUID:              ecx.String(d.Id()),
+		DeviceUUID:        ecx.String(randString(36)),
+		DeviceInterfaceID: ecx.Int(4),
+		RedundantUUID:     ecx.String(randString(36)),
+	}
+	secondary := &ecx.L2Connection{
+		UUID:              primary.RedundantUUID,
+		DeviceUUID:        ecx.String(randString(36)),
+		DeviceInterfaceID: ecx.Int(5),
+	}
+	//when
+	err := updateECXL2ConnectionResource(primary, secondary, d)
+	secondaryErr := updateECXL2ConnectionResource(primary, &ecx.L2Connection{UUID: primary.RedundantUUID}, d)
+	//then
+	assert.Nil(t, err, "Update of resource data does not return error")
+	assert.Equal(t, 4, d.Get(ecxL2ConnectionSchemaNames["DeviceInterfaceID"]), "Primary DeviceInterfaceID is populated from API")
+	assert.Nil(t, secondaryErr, "Update of resource data without reported secondary interface does not return error")
+	assert.Equal(t, 5, d.Get(ecxL2ConnectionSchemaNames["SecondaryConnection"]+".0."+ecxL2ConnectionSchemaNames["DeviceInterfaceID"]), "Secondary DeviceInterfaceID is kept when API does not report it")
+}
+
 type mockedL2ConnectionUpdateRequest struct {
 	name      string
 	speed     int
 	speedUnit string
+	executed  int
 }
 
 func (m *mockedL2ConnectionUpdateRequest) WithName(name string) ecx.L2ConnectionUpdateRequest {
@@ -295,6 +1391,7 @@ func (m *mockedL2ConnectionUpdateRequest) WithSpeedUnit(speedUnit string) ecx.L2
 }
 
 func (m *mockedL2ConnectionUpdateRequest) Execute() error {
+	m.executed++
 	return nil
 }
 
@@ -313,3 +1410,173 @@ func TestFabricL2Connection_fillUpdateRequest(t *testing.T) {
 	assert.Equal(t, changes[ecxL2ConnectionSchemaNames["Speed"]], updateReq.speed, "Update request speed matches")
 	assert.Equal(t, changes[ecxL2ConnectionSchemaNames["SpeedUnit"]], updateReq.speedUnit, "Update speed unit matches")
 }
+
+func TestFabricL2Connection_completeBandwidthChanges(t *testing.T) {
+	//given
+	speedOnly := map[string]interface{}{
+		ecxL2ConnectionSchemaNames["Speed"]: 100,
+	}
+	nameOnly := map[string]interface{}{
+		ecxL2ConnectionSchemaNames["Name"]: randString(32),
+	}
+	//when
+	speedOnlyResult := completeECXL2ConnectionBandwidthChanges(speedOnly, 100, "MB")
+	nameOnlyResult := completeECXL2ConnectionBandwidthChanges(nameOnly, 100, "MB")
+	//then
+	assert.Equal(t, "MB", speedOnlyResult[ecxL2ConnectionSchemaNames["SpeedUnit"]], "Speed unit is added to speed change")
+	assert.NotContains(t, nameOnlyResult, ecxL2ConnectionSchemaNames["Speed"], "Speed is not added when bandwidth did not change")
+}
+
+func TestFabricL2Connection_executeUpdates_redundant(t *testing.T) {
+	//given
+	primaryUUID := randString(36)
+	secondaryUUID := randString(36)
+	requests := make(map[string]*mockedL2ConnectionUpdateRequest)
+	newReqFunc := func(uuid string) ecx.L2ConnectionUpdateRequest {
+		req := &mockedL2ConnectionUpdateRequest{}
+		requests[uuid] = req
+		return req
+	}
+	primaryChanges := map[string]interface{}{
+		ecxL2ConnectionSchemaNames["Name"]:      randString(32),
+		ecxL2ConnectionSchemaNames["Speed"]:     200,
+		ecxL2ConnectionSchemaNames["SpeedUnit"]: "MB",
+	}
+	secondaryChanges := map[string]interface{}{
+		ecxL2ConnectionSchemaNames["Name"]:      randString(32),
+		ecxL2ConnectionSchemaNames["Speed"]:     1,
+		ecxL2ConnectionSchemaNames["SpeedUnit"]: "GB",
+	}
+	//when
+	updated, err := executeECXL2ConnectionUpdates(newReqFunc,
+		ecxL2ConnectionUpdate{uuid: primaryUUID, changes: primaryChanges},
+		ecxL2ConnectionUpdate{uuid: secondaryUUID, changes: secondaryChanges},
+		ecxL2ConnectionUpdate{uuid: randString(36)})
+	//then
+	assert.Nil(t, err, "Update does not return error")
+	assert.Equal(t, []string{primaryUUID, secondaryUUID}, updated, "Changed connections are updated")
+	assert.Len(t, requests, 2, "Connection without changes is not updated")
+	for uuid, changes := range map[string]map[string]interface{}{primaryUUID: primaryChanges, secondaryUUID: secondaryChanges} {
+		req := requests[uuid]
+		assert.Equal(t, 1, req.executed, "Single update request is sent per connection")
+		assert.Equal(t, changes[ecxL2ConnectionSchemaNames["Name"]], req.name, "Update request name matches")
+		assert.Equal(t, changes[ecxL2ConnectionSchemaNames["Speed"]], req.speed, "Update request speed matches")
+		assert.Equal(t, changes[ecxL2ConnectionSchemaNames["SpeedUnit"]], req.speedUnit, "Update request speed unit matches")
+	}
+}
+
+func TestFabricL2Connection_getBandwidthInMB(t *testing.T) {
+	//given
+	input := []struct {
+		speed     int
+		speedUnit string
+		expected  int
+	}{
+		{50, "MB", 50},
+		{10, "GB", 10000},
+		{1, "gb", 1000},
+	}
+	for _, tc := range input {
+		//when
+		bandwidth := getECXL2ConnectionBandwidthInMB(tc.speed, tc.speedUnit)
+		//then
+		assert.Equal(t, tc.expected, bandwidth, "Bandwidth in MB matches")
+	}
+}
+
+func TestFabricL2Connection_flattenActions(t *testing.T) {
+	//given
+	input := []ecx.L2ConnectionAction{
+		{
+			Type:        ecx.String("PROVIDER_ACTION"),
+			OperationID: ecx.String(ecxL2ConnectionActionConfirmConnection),
+			Message:     ecx.String("Confirm connection on provider's side"),
+			RequiredData: []ecx.L2ConnectionActionData{
+				{Key: ecx.String("awsConnectionId"), Value: ecx.String("dxcon-fgh4xyz1")},
+				{Key: ecx.String("pairingKey")},
+				{Value: ecx.String("dropped")},
+			},
+		},
+	}
+	expected := []interface{}{
+		map[string]interface{}{
+			ecxL2ConnectionActionSchemaNames["Type"]:        "PROVIDER_ACTION",
+			ecxL2ConnectionActionSchemaNames["OperationID"]: ecxL2ConnectionActionConfirmConnection,
+			ecxL2ConnectionActionSchemaNames["Message"]:     "Confirm connection on provider's side",
+			ecxL2ConnectionActionSchemaNames["RequiredData"]: map[string]interface{}{
+				"awsConnectionId": "dxcon-fgh4xyz1",
+				"pairingKey":      "",
+			},
+		},
+	}
+	//when
+	out := flattenECXL2ConnectionActions(input)
+	//then
+	assert.Equal(t, expected, out, "Flattened actions match expected result")
+	assert.Empty(t, flattenECXL2ConnectionActions(nil), "Connection without actions has no actions")
+}
+
+func TestFabricL2Connection_memoizeL2ServiceProfiles(t *testing.T) {
+	//given
+	profileUUID := randString(36)
+	calls := 0
+	fetchFunc := func(uuid string) (*ecx.L2ServiceProfile, error) {
+		calls++
+		return &ecx.L2ServiceProfile{UUID: ecx.String(uuid)}, nil
+	}
+	memoized := memoizeL2ServiceProfiles(fetchFunc)
+	//when
+	first, firstErr := memoized(profileUUID)
+	second, secondErr := memoized(profileUUID)
+	//then
+	assert.Nil(t, firstErr, "First fetch does not return error")
+	assert.Nil(t, secondErr, "Second fetch does not return error")
+	assert.Equal(t, first, second, "Same profile is returned")
+	assert.Equal(t, 1, calls, "Profile is fetched once")
+}
+
+func TestFabricL2Connection_memoizeUserPorts(t *testing.T) {
+	//given
+	calls := 0
+	fetchFunc := func() ([]ecx.Port, error) {
+		calls++
+		return []ecx.Port{{UUID: ecx.String(randString(36))}}, nil
+	}
+	memoized := memoizeUserPorts(fetchFunc)
+	//when
+	first, firstErr := memoized()
+	second, secondErr := memoized()
+	//then
+	assert.Nil(t, firstErr, "First fetch does not return error")
+	assert.Nil(t, secondErr, "Second fetch does not return error")
+	assert.Equal(t, first, second, "Same ports are returned")
+	assert.Equal(t, 1, calls, "Ports are fetched once")
+}
+
+func TestFabricL2Connection_flattenProfile(t *testing.T) {
+	//given
+	profile := &ecx.L2ServiceProfile{
+		Name:         ecx.String("AWS Direct Connect"),
+		AuthKeyLabel: ecx.String("AWS Account ID"),
+		SpeedBands: []ecx.L2ServiceProfileSpeedBand{
+			{Speed: ecx.Int(50), SpeedUnit: ecx.String("MB")},
+			{Speed: ecx.Int(1), SpeedUnit: ecx.String("GB")},
+		},
+	}
+	expected := []interface{}{
+		map[string]interface{}{
+			ecxL2ConnectionProfileSchemaNames["Name"]:                     "AWS Direct Connect",
+			ecxL2ConnectionProfileSchemaNames["SpeedBand"]:                flattenECXL2ServiceProfileSpeedBands(profile.SpeedBands),
+			ecxL2ConnectionProfileSchemaNames["AuthorizationKeyRequired"]: true,
+			ecxL2ConnectionProfileSchemaNames["AuthorizationKeyLabel"]:    "AWS Account ID",
+		},
+	}
+	//when
+	out := flattenECXL2ConnectionProfile(profile)
+	noAuthKeyOut := flattenECXL2ConnectionProfile(&ecx.L2ServiceProfile{Name: ecx.String("Seller Service")})
+	noProfileOut := flattenECXL2ConnectionProfile(nil)
+	//then
+	assert.Equal(t, expected, out, "Flattened profile matches expected result")
+	assert.Equal(t, false, noAuthKeyOut.([]interface{})[0].(map[string]interface{})[ecxL2ConnectionProfileSchemaNames["AuthorizationKeyRequired"]], "Profile without authorization key label does not require authorization key")
+	assert.Empty(t, noProfileOut, "Connection without profile has empty profile block")
+}
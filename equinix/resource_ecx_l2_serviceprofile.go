@@ -341,6 +341,8 @@ func createECXL2ServiceProfileResourceSchema() map[string]*schema.Schema {
 
 func resourceECXL2ServiceProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
 	var diags diag.Diagnostics
 	profile := createECXL2ServiceProfile(d)
 	uuid, err := conf.ecx.CreateL2ServiceProfile(*profile)
@@ -367,6 +369,8 @@ func resourceECXL2ServiceProfileRead(ctx context.Context, d *schema.ResourceData
 
 func resourceECXL2ServiceProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
 	var diags diag.Diagnostics
 	profile := createECXL2ServiceProfile(d)
 	if err := conf.ecx.UpdateL2ServiceProfile(*profile); err != nil {
@@ -378,6 +382,8 @@ func resourceECXL2ServiceProfileUpdate(ctx context.Context, d *schema.ResourceDa
 
 func resourceECXL2ServiceProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.ecxSemaphore.acquire()
+	defer conf.ecxSemaphore.release()
 	var diags diag.Diagnostics
 	if err := conf.ecx.DeleteL2ServiceProfile(d.Id()); err != nil {
 		restErr, ok := err.(rest.Error)
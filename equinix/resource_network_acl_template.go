@@ -158,6 +158,8 @@ func createNetworkACLTemplateInboundRuleSchema() map[string]*schema.Schema {
 
 func resourceNetworkACLTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	template := createACLTemplate(d)
 	uuid, err := conf.ne.CreateACLTemplate(template)
@@ -190,6 +192,8 @@ func resourceNetworkACLTemplateRead(ctx context.Context, d *schema.ResourceData,
 
 func resourceNetworkACLTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	template := createACLTemplate(d)
 	if err := conf.ne.ReplaceACLTemplate(d.Id(), template); err != nil {
@@ -201,6 +205,8 @@ func resourceNetworkACLTemplateUpdate(ctx context.Context, d *schema.ResourceDat
 
 func resourceNetworkACLTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	if devID, ok := d.GetOk(networkACLTemplateSchemaNames["DeviceUUID"]); ok {
 		if err := conf.ne.NewDeviceUpdateRequest(devID.(string)).WithACLTemplate("").Execute(); err != nil {
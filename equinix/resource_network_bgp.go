@@ -122,6 +122,8 @@ func createNetworkBGPResourceSchema() map[string]*schema.Schema {
 
 func resourceNetworkBGPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	bgp := createNetworkBGPConfiguration(d)
 	existingBGP, err := conf.ne.GetBGPConfigurationForConnection(ne.StringValue(bgp.ConnectionUUID))
@@ -164,6 +166,8 @@ func resourceNetworkBGPRead(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceNetworkBGPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	bgpConfig := createNetworkBGPConfiguration(d)
 	if err := createNetworkBGPUpdateRequest(conf.ne.NewBGPConfigurationUpdateRequest, &bgpConfig).Execute(); err != nil {
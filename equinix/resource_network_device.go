@@ -637,6 +637,8 @@ func createNetworkDeviceUserKeySchema() map[string]*schema.Schema {
 
 func resourceNetworkDeviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	primary, secondary := createNetworkDevices(d)
 	var err error
@@ -714,6 +716,8 @@ func resourceNetworkDeviceRead(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceNetworkDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	supportedChanges := []string{networkDeviceSchemaNames["Name"], networkDeviceSchemaNames["TermLength"],
 		networkDeviceSchemaNames["Notifications"], networkDeviceSchemaNames["AdditionalBandwidth"],
@@ -747,6 +751,8 @@ func resourceNetworkDeviceUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceNetworkDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	if v, ok := d.GetOk(networkDeviceSchemaNames["ACLTemplateUUID"]); ok {
 		if err := conf.ne.NewDeviceUpdateRequest(d.Id()).WithACLTemplate("").Execute(); err != nil {
@@ -214,6 +214,8 @@ func createNetworkDeviceLinkConnectionResourceSchema() map[string]*schema.Schema
 
 func resourceNetworkDeviceLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	link := createNetworkDeviceLink(d)
 	uuid, err := conf.ne.CreateDeviceLinkGroup(link)
@@ -251,6 +253,8 @@ func resourceNetworkDeviceLinkRead(ctx context.Context, d *schema.ResourceData,
 
 func resourceNetworkDeviceLinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	changes := getResourceDataChangedKeys([]string{
 		networkDeviceLinkSchemaNames["Name"], networkDeviceLinkSchemaNames["Subnet"],
@@ -288,6 +292,8 @@ func resourceNetworkDeviceLinkUpdate(ctx context.Context, d *schema.ResourceData
 
 func resourceNetworkDeviceLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	if err := conf.ne.DeleteDeviceLinkGroup(d.Id()); err != nil {
 		if isRestNotFoundError(err) {
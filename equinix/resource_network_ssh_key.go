@@ -66,6 +66,8 @@ func createNetworkSSHKeyResourceSchema() map[string]*schema.Schema {
 }
 func resourceNetworkSSHKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	key := createNetworkSSHKey(d)
 	uuid, err := conf.ne.CreateSSHPublicKey(key)
@@ -98,6 +100,8 @@ func resourceNetworkSSHKeyRead(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceNetworkSSHKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	conf := m.(*Config)
+	conf.neSemaphore.acquire()
+	defer conf.neSemaphore.release()
 	var diags diag.Diagnostics
 	if err := conf.ne.DeleteSSHPublicKey(d.Id()); err != nil {
 		if restErr, ok := err.(rest.Error); ok {
@@ -12,4 +12,5 @@ require (
 	github.com/hashicorp/terraform v0.14.8
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.4.4
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/oauth2 v0.0.0-20200902213428-5d25da1a8d43
 )